@@ -0,0 +1,166 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"fmt"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
+)
+
+// DefaultLanguage is the default language of the analysis
+const DefaultLanguage = "english"
+
+// Stemmer reduces words to their stems
+type Stemmer interface {
+	Stem(word string) string
+}
+
+var (
+	// Tokenizers are the tokenizers an analysis can select by name
+	Tokenizers = map[string]Tokenizer{
+		"unicode": UnicodeTokenizer{},
+	}
+	// Stemmers are the stemmers an analysis can select by language
+	Stemmers = map[string]Stemmer{
+		"english": PorterStemmer{},
+	}
+	// StopWords are the stop word lists of the languages
+	StopWords = map[string][]string{
+		"english": {
+			"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "if",
+			"in", "into", "is", "it", "no", "not", "of", "on", "or", "such",
+			"that", "the", "their", "then", "there", "these", "they", "this",
+			"to", "was", "will", "with",
+		},
+	}
+)
+
+// NewAnalysis returns the analysis of a language, the text is only tokenized
+// if the language is empty
+func NewAnalysis(language string) *Analysis {
+	return &Analysis{
+		Tokenizer: "unicode",
+		Stemmer:   language,
+		StopWords: StopWords[language],
+	}
+}
+
+// Analyzer is a tokenizer followed by a stop word filter and a stemmer
+type Analyzer struct {
+	Tokenizer Tokenizer
+	StopWords map[string]bool
+	Stemmer   Stemmer
+}
+
+// NewAnalyzer creates the analyzer selected by the analysis
+func NewAnalyzer(analysis *Analysis) (*Analyzer, error) {
+	tokenizer, has := Tokenizers[analysis.Tokenizer]
+	if !has {
+		return nil, fmt.Errorf("unknown tokenizer %s", analysis.Tokenizer)
+	}
+	analyzer := Analyzer{
+		Tokenizer: tokenizer,
+		StopWords: make(map[string]bool, len(analysis.StopWords)),
+	}
+	for _, word := range analysis.StopWords {
+		analyzer.StopWords[word] = true
+	}
+	if analysis.Stemmer != "" {
+		stemmer, has := Stemmers[analysis.Stemmer]
+		if !has {
+			return nil, fmt.Errorf("unknown stemmer %s", analysis.Stemmer)
+		}
+		analyzer.Stemmer = stemmer
+	}
+	return &analyzer, nil
+}
+
+// Token is a term and its position in the words of a text
+type Token struct {
+	Term     string
+	Position uint32
+}
+
+// Term returns the term of a word of the text, it is false if the word is a
+// stop word
+func (a *Analyzer) Term(word string) (string, bool) {
+	if a.StopWords[word] {
+		return "", false
+	}
+	if a.Stemmer != nil {
+		word = a.Stemmer.Stem(word)
+	}
+	return word, true
+}
+
+// Analyze splits the text into words, drops the stop words and stems the
+// remaining words. The positions of the terms count the dropped stop words.
+func (a *Analyzer) Analyze(text string) []Token {
+	words := a.Tokenizer.Tokenize(text)
+	tokens := make([]Token, 0, len(words))
+	for i, word := range words {
+		term, ok := a.Term(word)
+		if !ok {
+			continue
+		}
+		tokens = append(tokens, Token{
+			Term:     term,
+			Position: uint32(i),
+		})
+	}
+	return tokens
+}
+
+// Tokenize returns the terms of the analysis of the text
+func (a *Analyzer) Tokenize(text string) []string {
+	tokens := a.Analyze(text)
+	terms := make([]string, len(tokens))
+	for i, token := range tokens {
+		terms[i] = token.Term
+	}
+	return terms
+}
+
+// Terms returns the positions of the terms of the analysis of the text
+func (a *Analyzer) Terms(text string) map[string][]uint32 {
+	terms := make(map[string][]uint32)
+	for _, token := range a.Analyze(text) {
+		terms[token.Term] = append(terms[token.Term], token.Position)
+	}
+	return terms
+}
+
+// getAnalysis gets the analysis recorded in the meta bucket
+func getAnalysis(tx *bolt.Tx) (*Analysis, error) {
+	meta := tx.Bucket([]byte("meta"))
+	if meta == nil {
+		return nil, nil
+	}
+	value := meta.Get([]byte("analysis"))
+	if value == nil {
+		return nil, nil
+	}
+	analysis := Analysis{}
+	err := proto.Unmarshal(value, &analysis)
+	if err != nil {
+		return nil, err
+	}
+	return &analysis, nil
+}
+
+// putAnalysis records the analysis in the meta bucket
+func putAnalysis(tx *bolt.Tx, analysis *Analysis) error {
+	meta, err := tx.CreateBucketIfNotExists([]byte("meta"))
+	if err != nil {
+		return err
+	}
+	value, err := proto.Marshal(analysis)
+	if err != nil {
+		return err
+	}
+	return meta.Put([]byte("analysis"), value)
+}
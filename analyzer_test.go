@@ -0,0 +1,100 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boltdb/bolt"
+)
+
+func TestPorterStemmer(t *testing.T) {
+	stems := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"ties":           "ti",
+		"caress":         "caress",
+		"cats":           "cat",
+		"feed":           "feed",
+		"agreed":         "agre",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"sing":           "sing",
+		"conflated":      "conflat",
+		"hopping":        "hop",
+		"falling":        "fall",
+		"filing":         "file",
+		"happy":          "happi",
+		"relational":     "relat",
+		"generalization": "gener",
+		"running":        "run",
+		"connection":     "connect",
+		"cities":         "citi",
+		"city":           "citi",
+		"adjustable":     "adjust",
+		"controll":       "control",
+		"is":             "is",
+		"zürich":         "zürich",
+	}
+	for word, stem := range stems {
+		if s := (PorterStemmer{}).Stem(word); s != stem {
+			t.Fatal("invalid stem", word, s, stem)
+		}
+	}
+}
+
+func TestAnalyzer(t *testing.T) {
+	analyzer, err := NewAnalyzer(NewAnalysis(DefaultLanguage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	terms := analyzer.Tokenize("The apples are running to the Cities")
+	if strings.Join(terms, "|") != "appl|run|citi" {
+		t.Fatal("invalid terms", terms)
+	}
+	_, err = NewAnalyzer(NewAnalysis("klingon"))
+	if err == nil {
+		t.Fatal("unknown stemmer should fail")
+	}
+}
+
+func TestSearchAnalysis(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	test := func(encyclopedia *Encyclopedia, query string, expected int) {
+		results, err := encyclopedia.Search(SearchRequest{Query: query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results.Results) != expected {
+			t.Fatal("invalid number of results", query, len(results.Results))
+		}
+	}
+	test(encyclopedia, "runs", 1)
+	test(encyclopedia, "the", 0)
+
+	// the analysis recorded in the db is used instead of the options
+	unstemmed, cleanup := testBuild(t, func(dir string) Options {
+		options := testOptions(dir)
+		options.Analysis = NewAnalysis("")
+		return options
+	})
+	defer cleanup()
+	options := unstemmed.Options
+	options.Analysis = nil
+	options.Bolt = &bolt.Options{ReadOnly: true}
+	reopened, err := Open(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if reopened.Options.Analysis.Stemmer != "" {
+		t.Fatal("the recorded analysis should be used", reopened.Options.Analysis)
+	}
+	test(reopened, "runs", 0)
+	test(reopened, "running", 1)
+	test(reopened, "the", 4)
+}
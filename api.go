@@ -0,0 +1,549 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	// MediaTypeJSON is the media type of the json api
+	MediaTypeJSON = "application/json"
+	// MediaTypeHTML is the media type of the html of an article
+	MediaTypeHTML = "text/html"
+	// MediaTypeWikiText is the media type of the wikitext of an article
+	MediaTypeWikiText = "text/x-wiki"
+)
+
+// APIError is the body of an error of the json api
+type APIError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	// Titles are the titles of the articles of an ambiguous title
+	Titles []string `json:"titles,omitempty"`
+}
+
+// APIArticle is an article of the json api
+type APIArticle struct {
+	Title     string `json:"title"`
+	ID        uint64 `json:"id"`
+	Namespace int32  `json:"namespace"`
+	// Redirect is the title of the redirect followed by the lookup
+	Redirect    string `json:"redirect,omitempty"`
+	Anchor      string `json:"anchor,omitempty"`
+	Revision    uint64 `json:"revision,omitempty"`
+	Parent      uint64 `json:"parent,omitempty"`
+	Timestamp   string `json:"timestamp,omitempty"`
+	Contributor string `json:"contributor,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+	SHA1        string `json:"sha1,omitempty"`
+	// Verified is true if the wikitext matches the sha1
+	Verified bool   `json:"verified"`
+	WikiText string `json:"wikitext"`
+	HTML     string `json:"html"`
+	// Infobox is the infobox of the article if it has one
+	Infobox *Infobox `json:"infobox,omitempty"`
+}
+
+// APIInfobox is the infobox of an article of the json api
+type APIInfobox struct {
+	Title  string  `json:"title"`
+	Type   string  `json:"type"`
+	Fields []Field `json:"fields"`
+}
+
+// APIResult is a search result of the json api
+type APIResult struct {
+	Title     string    `json:"title"`
+	Namespace int32     `json:"namespace"`
+	Score     float64   `json:"score"`
+	Rank      float32   `json:"rank"`
+	Count     int       `json:"count"`
+	Matches   int       `json:"matches"`
+	Snippets  []Snippet `json:"snippets"`
+}
+
+// APIResults are a page of search results of the json api
+type APIResults struct {
+	Query      string      `json:"query"`
+	Suggestion string      `json:"suggestion,omitempty"`
+	Corrected  bool        `json:"corrected,omitempty"`
+	Total      int         `json:"total"`
+	Scored     int         `json:"scored"`
+	Page       int         `json:"page"`
+	PerPage    int         `json:"per_page"`
+	Results    []APIResult `json:"results"`
+}
+
+// APICompletions are the completions of a prefix of the json api
+type APICompletions struct {
+	Prefix string   `json:"prefix"`
+	Titles []string `json:"titles"`
+}
+
+// negotiate returns the offered media type the accept header of the request
+// prefers, the first offer if there is no accept header, or empty if no
+// offer is acceptable
+func negotiate(r *http.Request, offers ...string) string {
+	header := r.Header.Get("Accept")
+	if strings.TrimSpace(header) == "" {
+		return offers[0]
+	}
+	best, quality := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		parameters := strings.Split(part, ";")
+		accept, q := strings.ToLower(strings.TrimSpace(parameters[0])), 1.0
+		for _, parameter := range parameters[1:] {
+			parameter = strings.TrimSpace(parameter)
+			if strings.HasPrefix(parameter, "q=") {
+				if value, err := strconv.ParseFloat(parameter[2:], 64); err == nil {
+					q = value
+				}
+			}
+		}
+		if q <= quality {
+			continue
+		}
+		for _, offer := range offers {
+			if accept == offer || accept == "*/*" ||
+				(strings.HasSuffix(accept, "/*") && strings.HasPrefix(offer, accept[:len(accept)-1])) {
+				best, quality = offer, q
+				break
+			}
+		}
+	}
+	return best
+}
+
+// writeJSON writes a value of the json api
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", MediaTypeJSON)
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		return
+	}
+}
+
+// writeError writes an error of the json api
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, struct {
+		Error APIError `json:"error"`
+	}{APIError{Status: status, Message: message}})
+}
+
+// acceptJSON writes an error if the request doesn't accept json
+func acceptJSON(w http.ResponseWriter, r *http.Request) bool {
+	if negotiate(r, MediaTypeJSON) == "" {
+		writeError(w, http.StatusNotAcceptable, "only "+MediaTypeJSON+" is available")
+		return false
+	}
+	return true
+}
+
+// APIArticle is the json api endpoint for an article, the article is json,
+// html or wikitext depending on the accept header
+func (e *Encyclopedia) APIArticle(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	w.Header().Set("Vary", "Accept")
+	format := negotiate(r, MediaTypeJSON, MediaTypeHTML, MediaTypeWikiText)
+	if format == "" {
+		writeError(w, http.StatusNotAcceptable, fmt.Sprintf("only %s, %s and %s are available",
+			MediaTypeJSON, MediaTypeHTML, MediaTypeWikiText))
+		return
+	}
+	article := e.apiLookup(w, ps)
+	if article == nil {
+		return
+	}
+
+	if format == MediaTypeWikiText {
+		w.Header().Set("Content-Type", MediaTypeWikiText+"; charset=utf-8")
+		w.Write([]byte(article.Text))
+		return
+	}
+	html, err := e.Render(article)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if format == MediaTypeHTML {
+		w.Header().Set("Content-Type", MediaTypeHTML+"; charset=utf-8")
+		w.Write([]byte(html))
+		return
+	}
+	infobox, err := e.Infobox(article)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, APIArticle{
+		Title:       article.Title,
+		ID:          article.ID,
+		Namespace:   article.Namespace,
+		Redirect:    article.Redirect,
+		Anchor:      article.Anchor,
+		Revision:    article.Revision,
+		Parent:      article.Parent,
+		Timestamp:   article.Timestamp,
+		Contributor: article.Contributor(),
+		Comment:     article.Comment,
+		SHA1:        article.SHA1,
+		Verified:    article.Verify(),
+		WikiText:    article.Text,
+		HTML:        html,
+		Infobox:     infobox,
+	})
+}
+
+// apiLookup looks up the article of the title parameter, the error is written
+// and the article is nil if it isn't found
+func (e *Encyclopedia) apiLookup(w http.ResponseWriter, ps httprouter.Params) *Article {
+	title := strings.TrimSpace(strings.TrimPrefix(ps.ByName("title"), "/"))
+	if title == "" {
+		writeError(w, http.StatusBadRequest, "missing article title")
+		return nil
+	}
+	article, err := e.Lookup(title)
+	if ambiguous, ok := err.(*AmbiguousError); ok {
+		writeJSON(w, http.StatusMultipleChoices, struct {
+			Error APIError `json:"error"`
+		}{APIError{
+			Status:  http.StatusMultipleChoices,
+			Message: ambiguous.Error(),
+			Titles:  ambiguous.Titles,
+		}})
+		return nil
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil
+	}
+	if article == nil {
+		writeError(w, http.StatusNotFound, "article not found: "+title)
+	}
+	return article
+}
+
+// APIInfobox is the json api endpoint for the infobox of an article
+func (e *Encyclopedia) APIInfobox(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if !acceptJSON(w, r) {
+		return
+	}
+	article := e.apiLookup(w, ps)
+	if article == nil {
+		return
+	}
+	infobox, err := e.Infobox(article)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if infobox == nil {
+		writeError(w, http.StatusNotFound, "article has no infobox: "+article.Title)
+		return
+	}
+	writeJSON(w, http.StatusOK, APIInfobox{
+		Title:  article.Title,
+		Type:   infobox.Type,
+		Fields: infobox.Fields,
+	})
+}
+
+// APISearch is the json api endpoint for searching for articles
+func (e *Encyclopedia) APISearch(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if !acceptJSON(w, r) {
+		return
+	}
+	values := r.URL.Query()
+	query := values.Get("q")
+	if strings.TrimSpace(query) == "" {
+		writeError(w, http.StatusBadRequest, "missing query q")
+		return
+	}
+	request, page, err := e.parseSearch(values, query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	results, err := e.Search(request)
+	if _, ok := err.(*QueryError); ok {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	response := APIResults{
+		Query:      query,
+		Suggestion: results.Suggestion,
+		Corrected:  results.Corrected,
+		Total:      results.Total,
+		Scored:     results.Scored,
+		Page:       page,
+		PerPage:    request.Limit,
+		Results:    make([]APIResult, 0, len(results.Results)),
+	}
+	for _, result := range results.Results {
+		response.Results = append(response.Results, APIResult{
+			Title:     result.Article.Title,
+			Namespace: result.Article.Namespace,
+			Score:     result.Score,
+			Rank:      result.Rank,
+			Count:     result.Count,
+			Matches:   result.Matches,
+			Snippets:  result.Snippets,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// APIComplete is the json api endpoint for completing the prefix of a title
+func (e *Encyclopedia) APIComplete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if !acceptJSON(w, r) {
+		return
+	}
+	values := r.URL.Query()
+	n := DefaultCompletions
+	if text := values.Get("n"); text != "" {
+		var err error
+		n, err = strconv.Atoi(text)
+		if err != nil || n < 1 || n > MaxPerPage {
+			writeError(w, http.StatusBadRequest, "invalid n "+text)
+			return
+		}
+	}
+	prefix := values.Get("q")
+	titles, err := e.Complete(prefix, n)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, APICompletions{
+		Prefix: prefix,
+		Titles: titles,
+	})
+}
+
+// APIOpenAPI is the json api endpoint for the OpenAPI document of the api
+func APIOpenAPI(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if !acceptJSON(w, r) {
+		return
+	}
+	w.Header().Set("Content-Type", MediaTypeJSON)
+	w.Write([]byte(OpenAPI))
+}
+
+// OpenAPI is the OpenAPI document of the json api
+const OpenAPI = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Encyclopedia",
+    "description": "The articles of a wikipedia dump and their search",
+    "version": "1"
+  },
+  "servers": [{"url": "/api/v1"}],
+  "paths": {
+    "/article/{title}": {
+      "get": {
+        "summary": "Look up an article, redirects are followed",
+        "parameters": [
+          {"name": "title", "in": "path", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {
+          "200": {
+            "description": "The article",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/Article"}},
+              "text/html": {"schema": {"type": "string"}},
+              "text/x-wiki": {"schema": {"type": "string"}}
+            }
+          },
+          "300": {"$ref": "#/components/responses/Error"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "406": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/infobox/{title}": {
+      "get": {
+        "summary": "Get the fields of the infobox of an article, redirects are followed",
+        "parameters": [
+          {"name": "title", "in": "path", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {
+          "200": {
+            "description": "The infobox",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ArticleInfobox"}}}
+          },
+          "300": {"$ref": "#/components/responses/Error"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "406": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/search": {
+      "get": {
+        "summary": "Search for articles",
+        "parameters": [
+          {"name": "q", "in": "query", "required": true, "schema": {"type": "string"}},
+          {"name": "ns", "in": "query", "description": "The namespaces by id or name", "schema": {"type": "array", "items": {"type": "string"}}},
+          {"name": "page", "in": "query", "schema": {"type": "integer", "minimum": 1, "default": 1}},
+          {"name": "per_page", "in": "query", "schema": {"type": "integer", "minimum": 1, "maximum": 100, "default": 20}}
+        ],
+        "responses": {
+          "200": {
+            "description": "A page of the results",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Results"}}}
+          },
+          "400": {"$ref": "#/components/responses/Error"},
+          "406": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/complete": {
+      "get": {
+        "summary": "Complete the prefix of a title, the titles are ordered by page rank",
+        "parameters": [
+          {"name": "q", "in": "query", "schema": {"type": "string"}},
+          {"name": "n", "in": "query", "schema": {"type": "integer", "minimum": 1, "maximum": 100, "default": 10}}
+        ],
+        "responses": {
+          "200": {
+            "description": "The completions",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Completions"}}}
+          },
+          "400": {"$ref": "#/components/responses/Error"},
+          "406": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "summary": "This document",
+        "responses": {
+          "200": {"description": "The OpenAPI document", "content": {"application/json": {}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "responses": {
+      "Error": {
+        "description": "An error",
+        "content": {
+          "application/json": {
+            "schema": {
+              "type": "object",
+              "properties": {"error": {"$ref": "#/components/schemas/Error"}}
+            }
+          }
+        }
+      }
+    },
+    "schemas": {
+      "Error": {
+        "type": "object",
+        "properties": {
+          "status": {"type": "integer"},
+          "message": {"type": "string"},
+          "titles": {"type": "array", "items": {"type": "string"}, "description": "The titles of an ambiguous title"}
+        }
+      },
+      "Article": {
+        "type": "object",
+        "properties": {
+          "title": {"type": "string"},
+          "id": {"type": "integer"},
+          "namespace": {"type": "integer"},
+          "redirect": {"type": "string", "description": "The title of the redirect followed"},
+          "anchor": {"type": "string"},
+          "revision": {"type": "integer"},
+          "parent": {"type": "integer"},
+          "timestamp": {"type": "string"},
+          "contributor": {"type": "string"},
+          "comment": {"type": "string"},
+          "sha1": {"type": "string"},
+          "verified": {"type": "boolean", "description": "True if the wikitext matches the sha1"},
+          "wikitext": {"type": "string"},
+          "html": {"type": "string"},
+          "infobox": {"$ref": "#/components/schemas/Infobox"}
+        }
+      },
+      "Field": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "value": {"type": "string", "description": "The wikitext of the value"},
+          "text": {"type": "string", "description": "The plain text of the value"}
+        }
+      },
+      "Infobox": {
+        "type": "object",
+        "properties": {
+          "type": {"type": "string", "description": "The name of the infobox template without the Infobox prefix"},
+          "fields": {"type": "array", "items": {"$ref": "#/components/schemas/Field"}}
+        }
+      },
+      "ArticleInfobox": {
+        "type": "object",
+        "properties": {
+          "title": {"type": "string"},
+          "type": {"type": "string"},
+          "fields": {"type": "array", "items": {"$ref": "#/components/schemas/Field"}}
+        }
+      },
+      "Fragment": {
+        "type": "object",
+        "properties": {
+          "text": {"type": "string"},
+          "match": {"type": "boolean", "description": "True if the text is a term of the query"}
+        }
+      },
+      "Result": {
+        "type": "object",
+        "properties": {
+          "title": {"type": "string"},
+          "namespace": {"type": "integer"},
+          "score": {"type": "number"},
+          "rank": {"type": "number"},
+          "count": {"type": "integer", "description": "The number of clauses of the query matched"},
+          "matches": {"type": "integer"},
+          "snippets": {
+            "type": "array",
+            "items": {"type": "array", "items": {"$ref": "#/components/schemas/Fragment"}}
+          }
+        }
+      },
+      "Results": {
+        "type": "object",
+        "properties": {
+          "query": {"type": "string"},
+          "suggestion": {"type": "string", "description": "The query with the misspelled words corrected"},
+          "corrected": {"type": "boolean", "description": "True if the results are of the suggestion"},
+          "total": {"type": "integer", "description": "The number of matching articles"},
+          "scored": {"type": "integer", "description": "The number of matching articles that are scored, the results are the scored articles"},
+          "page": {"type": "integer"},
+          "per_page": {"type": "integer"},
+          "results": {"type": "array", "items": {"$ref": "#/components/schemas/Result"}}
+        }
+      },
+      "Completions": {
+        "type": "object",
+        "properties": {
+          "prefix": {"type": "string"},
+          "titles": {"type": "array", "items": {"type": "string"}}
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,176 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestNegotiate(t *testing.T) {
+	test := func(accept, expected string) {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("Accept", accept)
+		if format := negotiate(request, MediaTypeJSON, MediaTypeHTML); format != expected {
+			t.Fatal("invalid media type", accept, format)
+		}
+	}
+	test("", MediaTypeJSON)
+	test("*/*", MediaTypeJSON)
+	test("text/html", MediaTypeHTML)
+	test("text/*", MediaTypeHTML)
+	test("text/html;q=0.5, application/json", MediaTypeJSON)
+	test("text/html, application/json;q=0.9", MediaTypeHTML)
+	test("application/json;q=0", "")
+	test("image/png", "")
+}
+
+func TestAPI(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	router := httprouter.New()
+	err := Server(encyclopedia, router)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(path, accept string, value interface{}) *httptest.ResponseRecorder {
+		recorder, request := httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			request.Header.Set("Accept", accept)
+		}
+		router.ServeHTTP(recorder, request)
+		if value != nil {
+			if recorder.Header().Get("Content-Type") != MediaTypeJSON {
+				t.Fatal("the response should be json", path, recorder.Header().Get("Content-Type"))
+			}
+			err := json.Unmarshal(recorder.Body.Bytes(), value)
+			if err != nil {
+				t.Fatal(err, recorder.Body.String())
+			}
+		}
+		return recorder
+	}
+	type Error struct {
+		Error APIError `json:"error"`
+	}
+
+	article := APIArticle{}
+	if recorder := get("/api/v1/article/USA", "", &article); recorder.Code != http.StatusOK {
+		t.Fatal("article should be found", recorder.Code)
+	}
+	if article.Title != "United States" || article.Redirect != "USA" || !article.Verified ||
+		!strings.Contains(article.WikiText, "[[") || !strings.Contains(article.HTML, "<a") {
+		t.Fatal("invalid article", article)
+	}
+	if recorder := get("/api/v1/article/"+url.PathEscape("United States"), MediaTypeWikiText, nil); recorder.Code != http.StatusOK ||
+		recorder.Body.String() != article.WikiText || !strings.HasPrefix(recorder.Header().Get("Content-Type"), MediaTypeWikiText) {
+		t.Fatal("the wikitext should be negotiated", recorder.Code, recorder.Header())
+	}
+	if recorder := get("/api/v1/article/"+url.PathEscape("United States"), "text/html, application/json;q=0.5", nil); recorder.Code != http.StatusOK ||
+		recorder.Body.String() != article.HTML {
+		t.Fatal("the html should be negotiated", recorder.Code, recorder.Header())
+	}
+	failure := Error{}
+	if recorder := get("/api/v1/article/Atlantis", "", &failure); recorder.Code != http.StatusNotFound ||
+		failure.Error.Status != http.StatusNotFound {
+		t.Fatal("article should not be found", recorder.Code, failure)
+	}
+	failure = Error{}
+	if recorder := get("/api/v1/article/usa", "", &failure); recorder.Code != http.StatusMultipleChoices ||
+		strings.Join(failure.Error.Titles, "|") != "USA|Usa" {
+		t.Fatal("article should be ambiguous", recorder.Code, failure)
+	}
+	failure = Error{}
+	if recorder := get("/api/v1/article/USA", "image/png", &failure); recorder.Code != http.StatusNotAcceptable ||
+		failure.Error.Message == "" {
+		t.Fatal("image should not be acceptable", recorder.Code, failure)
+	}
+
+	infobox := APIInfobox{}
+	if recorder := get("/api/v1/infobox/"+url.PathEscape("Zürich"), "", &infobox); recorder.Code != http.StatusOK {
+		t.Fatal("infobox should be found", recorder.Code)
+	}
+	if infobox.Title != "Zürich" || infobox.Type != "settlement" || len(infobox.Fields) != 4 ||
+		infobox.Fields[1].Name != "country" || infobox.Fields[1].Text != "Switzerland" {
+		t.Fatal("invalid infobox", infobox)
+	}
+	failure = Error{}
+	if recorder := get("/api/v1/infobox/USA", "", &failure); recorder.Code != http.StatusNotFound ||
+		failure.Error.Status != http.StatusNotFound {
+		t.Fatal("infobox should not be found", recorder.Code, failure)
+	}
+	article = APIArticle{}
+	if recorder := get("/api/v1/article/"+url.PathEscape("Zürich"), "", &article); recorder.Code != http.StatusOK {
+		t.Fatal("article should be found", recorder.Code)
+	}
+	if field, has := article.Infobox.Get("population_total"); !has || field.Value != "421878" ||
+		!strings.HasPrefix(article.HTML, `<table class="infobox">`) {
+		t.Fatal("invalid article", article.Infobox, article.HTML)
+	}
+
+	results := APIResults{}
+	if recorder := get("/api/v1/search?q=york&per_page=3&page=2", "", &results); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	}
+	if results.Total != 4 || results.Page != 2 || results.PerPage != 3 || len(results.Results) != 1 {
+		t.Fatal("invalid results", results)
+	}
+	results = APIResults{}
+	if recorder := get("/api/v1/search?q=capital+-country", MediaTypeJSON, &results); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	}
+	if len(results.Results) != 1 || results.Results[0].Title != "Washington, D.C." ||
+		results.Results[0].Count != 1 || len(results.Results[0].Snippets) != 1 {
+		t.Fatal("invalid results", results)
+	}
+	results = APIResults{}
+	if recorder := get("/api/v1/search?q=aple", "", &results); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	}
+	if !results.Corrected || results.Suggestion != "apple" || len(results.Results) != 1 {
+		t.Fatal("the corrected query should be answered", results)
+	}
+	results = APIResults{}
+	if recorder := get("/api/v1/search?q=discussion&ns=Talk", "", &results); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	}
+	if len(results.Results) != 1 || results.Results[0].Title != "Talk:United States" {
+		t.Fatal("the namespace should be found by name", results)
+	}
+	for _, path := range []string{"/api/v1/search", "/api/v1/search?q=york&page=0", "/api/v1/search?q=c%2B%2B+(", "/api/v1/search?q=york&ns=Nowhere", "/api/v1/complete?q=new&n=0"} {
+		failure = Error{}
+		if recorder := get(path, "", &failure); recorder.Code != http.StatusBadRequest ||
+			failure.Error.Status != http.StatusBadRequest {
+			t.Fatal("request should be bad", path, recorder.Code, failure)
+		}
+	}
+
+	completions := APICompletions{}
+	if recorder := get("/api/v1/complete?q=new", "", &completions); recorder.Code != http.StatusOK {
+		t.Fatal("completion should succeed", recorder.Code)
+	}
+	if completions.Prefix != "new" || strings.Join(completions.Titles, "|") != "New York City" {
+		t.Fatal("invalid completions", completions)
+	}
+
+	document := struct {
+		Paths map[string]interface{} `json:"paths"`
+	}{}
+	if recorder := get("/api/v1/openapi.json", "", &document); recorder.Code != http.StatusOK {
+		t.Fatal("the openapi document should be served", recorder.Code)
+	}
+	for _, path := range []string{"/article/{title}", "/infobox/{title}", "/search", "/complete"} {
+		if document.Paths[path] == nil {
+			t.Fatal("the path should be documented", path)
+		}
+	}
+}
@@ -7,67 +7,215 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/pointlander/wikipedia"
 
+	"github.com/boltdb/bolt"
 	"github.com/julienschmidt/httprouter"
+	"google.golang.org/grpc"
 )
 
 var (
 	// BuildFlag selects build mode
 	BuildFlag = flag.Bool("build", false, "build the db")
+	// UpdateFlag selects update mode
+	UpdateFlag = flag.Bool("update", false, "update the db from a newer dump")
+	// ChangesFlag the dump of an update only holds added and changed pages
+	ChangesFlag = flag.Bool("changes", false, "the dump of an update only holds added and changed pages")
 	// RankFlag ranks the pages
 	RankFlag = flag.Bool("rank", false, "build the db")
 	// LookupFlag selects looking up an entry
 	LookupFlag = flag.String("lookup", "", "look up an entry")
 	// SearchFlag searches for the text
 	SearchFlag = flag.String("search", "", "searches for the text")
+	// CompleteFlag completes the prefix of a title
+	CompleteFlag = flag.String("complete", "", "completes the prefix of a title")
 	// ServerFlag startup in server mode
 	ServerFlag = flag.Bool("server", false, "start up in server mode")
+	// GRPCFlag startup in grpc server mode
+	GRPCFlag = flag.Bool("grpc", false, "start up in grpc server mode")
+	// AddressFlag is the address the server listens on
+	AddressFlag = flag.String("address", "", "address the server listens on, :8080 for http and :9090 for grpc by default")
+	// DumpFlag is the path to the wikipedia dump
+	DumpFlag = flag.String("dump", wikipedia.DefaultDump, "path to the wikipedia dump")
+	// IndexFlag is the path to the index of a multistream dump
+	IndexFlag = flag.String("index", "", "path to the index of a multistream dump")
+	// DBFlag is the path to the database
+	DBFlag = flag.String("db", wikipedia.DefaultDB, "path to the database")
+	// MemoryFlag is the memory ceiling in gigabytes
+	MemoryFlag = flag.Float64("memory", wikipedia.DefaultMemory, "memory ceiling in gigabytes")
+	// CheckpointFlag is the number of pages between checkpoints of a build
+	CheckpointFlag = flag.Int("checkpoint", wikipedia.DefaultCheckpoint, "number of pages between checkpoints of a build")
+	// IncludeFlag are the namespaces included by a build
+	IncludeFlag = flag.String("include", "", "comma separated namespace ids included by a build")
+	// ExcludeFlag are the namespaces excluded by a build
+	ExcludeFlag = flag.String("exclude", "", "comma separated namespace ids excluded by a build")
+	// NamespacesFlag are the namespaces a search is restricted to
+	NamespacesFlag = flag.String("ns", "", "comma separated namespaces, ids or names, a search is restricted to")
+	// LanguageFlag is the language of the analysis of a build
+	LanguageFlag = flag.String("language", wikipedia.DefaultLanguage, "language of the stop words and stemmer of a build, empty for none")
+	// K1Flag is the term frequency saturation of BM25
+	K1Flag = flag.Float64("k1", wikipedia.DefaultK1, "term frequency saturation of BM25")
+	// BFlag is the length normalization of BM25
+	BFlag = flag.Float64("b", wikipedia.DefaultB, "length normalization of BM25")
+	// WeightFlag is the weight of the page rank in the score of a search result
+	WeightFlag = flag.Float64("weight", wikipedia.DefaultWeight, "weight of the page rank in the score of a search result")
+	// RegexFlag enables the regular expression terms of search queries
+	RegexFlag = flag.Bool("regex", false, "enable /pattern/ regular expression terms in search queries")
+	// OffsetFlag is the number of search results to skip
+	OffsetFlag = flag.Int("offset", 0, "number of search results to skip")
+	// LimitFlag is the number of search results to print
+	LimitFlag = flag.Int("limit", wikipedia.DefaultLimit, "number of search results to print")
+	// CandidatesFlag is the number of matching articles scored by a search
+	CandidatesFlag = flag.Int("candidates", wikipedia.DefaultCandidates, "maximum number of matching articles scored by a search")
+	// TimeoutFlag is how long to wait for the database lock
+	TimeoutFlag = flag.Duration("timeout", 0, "how long to wait for the database lock")
 )
 
+// options returns the options selected by the flags
+func options(readonly bool) wikipedia.Options {
+	include, err := wikipedia.ParseNamespaces(*IncludeFlag, nil)
+	if err != nil {
+		panic(err)
+	}
+	exclude, err := wikipedia.ParseNamespaces(*ExcludeFlag, nil)
+	if err != nil {
+		panic(err)
+	}
+	return wikipedia.Options{
+		Dump:    *DumpFlag,
+		Index:   *IndexFlag,
+		Changes: *ChangesFlag,
+		DB:      *DBFlag,
+		Bolt: &bolt.Options{
+			ReadOnly: readonly,
+			Timeout:  *TimeoutFlag,
+		},
+		Memory:     *MemoryFlag,
+		Checkpoint: *CheckpointFlag,
+		Include:    include,
+		Exclude:    exclude,
+		Analysis:   wikipedia.NewAnalysis(*LanguageFlag),
+		K1:         K1Flag,
+		B:          BFlag,
+		Weight:     WeightFlag,
+		Regex:      *RegexFlag,
+	}
+}
+
 func main() {
 	flag.Parse()
 
 	if *BuildFlag {
-		wikipedia.Build()
+		err := wikipedia.Build(options(false))
+		if err != nil {
+			panic(err)
+		}
+		return
+	} else if *UpdateFlag {
+		err := wikipedia.Update(options(false))
+		if err != nil {
+			panic(err)
+		}
 		return
 	} else if *RankFlag {
-		wikipedia.Rank()
+		err := wikipedia.Rank(options(false))
+		if err != nil {
+			panic(err)
+		}
 		return
 	} else if *LookupFlag != "" {
-		db, err := wikipedia.Open(true)
+		db, err := wikipedia.Open(options(true))
 		if err != nil {
 			panic(err)
 		}
-		article := db.Lookup(*LookupFlag)
+		article, err := db.Lookup(*LookupFlag)
+		if ambiguous, ok := err.(*wikipedia.AmbiguousError); ok {
+			fmt.Println(ambiguous.Title, "may refer to:")
+			for _, title := range ambiguous.Titles {
+				fmt.Println(title)
+			}
+			return
+		} else if err != nil {
+			panic(err)
+		}
 		if article != nil {
+			html, err := db.Render(article)
+			if err != nil {
+				panic(err)
+			}
 			fmt.Println(article.Title)
-			fmt.Println(article.HTML())
+			fmt.Println("revision", article.Revision, article.Timestamp, article.Contributor(), "verified", article.Verify())
+			if article.Redirect != "" {
+				fmt.Println("redirected from", article.Redirect, article.Anchor)
+			}
+			fmt.Println(html)
+		}
+		return
+	} else if *CompleteFlag != "" {
+		db, err := wikipedia.Open(options(true))
+		if err != nil {
+			panic(err)
+		}
+		titles, err := db.Complete(*CompleteFlag, *LimitFlag)
+		if err != nil {
+			panic(err)
+		}
+		for _, title := range titles {
+			fmt.Println(title)
 		}
 		return
 	} else if *SearchFlag != "" {
-		db, err := wikipedia.Open(true)
+		db, err := wikipedia.Open(options(true))
+		if err != nil {
+			panic(err)
+		}
+		namespaces, err := db.ParseNamespaces(*NamespacesFlag)
+		if err != nil {
+			panic(err)
+		}
+		results, err := db.Search(wikipedia.SearchRequest{
+			Query:      *SearchFlag,
+			Namespaces: namespaces,
+			Offset:     *OffsetFlag,
+			Limit:      *LimitFlag,
+			Candidates: *CandidatesFlag,
+		})
 		if err != nil {
 			panic(err)
 		}
-		results := db.Search(*SearchFlag)
-		fmt.Println("results=", len(results))
-		for _, result := range results {
-			fmt.Println(result.Rank, result.Count)
+		if results.Corrected {
+			fmt.Println("showing results for", results.Suggestion)
+		} else if results.Suggestion != "" {
+			fmt.Println("did you mean", results.Suggestion)
+		}
+		fmt.Println("results=", results.Total)
+		for _, result := range results.Results {
+			fmt.Println(result.Score, result.Rank, result.Count)
 			fmt.Println(result.Article.Title)
+			for _, snippet := range result.Snippets {
+				fmt.Println(" ", snippet)
+			}
 		}
 		return
 	} else if *ServerFlag {
 		router := httprouter.New()
-		db, err := wikipedia.Open(true)
+		db, err := wikipedia.Open(options(true))
+		if err != nil {
+			panic(err)
+		}
+		err = wikipedia.Server(db, router)
 		if err != nil {
 			panic(err)
 		}
-		wikipedia.Server(db, router)
+		address := *AddressFlag
+		if address == "" {
+			address = ":8080"
+		}
 		server := http.Server{
-			Addr:    ":8080",
+			Addr:    address,
 			Handler: router,
 		}
 		err = server.ListenAndServe()
@@ -75,5 +223,25 @@ func main() {
 			panic(err)
 		}
 		return
+	} else if *GRPCFlag {
+		db, err := wikipedia.Open(options(true))
+		if err != nil {
+			panic(err)
+		}
+		address := *AddressFlag
+		if address == "" {
+			address = ":9090"
+		}
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			panic(err)
+		}
+		server := grpc.NewServer()
+		wikipedia.GRPCServer(db, server)
+		err = server.Serve(listener)
+		if err != nil {
+			panic(err)
+		}
+		return
 	}
 }
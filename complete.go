@@ -0,0 +1,198 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// DefaultCompletions is the default number of completions of a prefix
+	DefaultCompletions = 10
+)
+
+var (
+	// MaxCompletionScan is the maximum number of titles with a prefix that
+	// are read by a completion, the completions are the highest ranked of
+	// the first titles in the order of the normalized titles
+	MaxCompletionScan = 1 << 14
+)
+
+// NormalizeTitle normalizes a title so that titles differing in case,
+// underscores, white space or unicode normalization form are equal
+func NormalizeTitle(title string) string {
+	title = strings.Join(strings.Fields(strings.Replace(title, "_", " ", -1)), " ")
+	return norm.NFC.String(cases.Fold().String(title))
+}
+
+// titleKey is the key of a title in the titles bucket, the normalized title
+// is followed by the title so that titles that normalize the same are kept
+// apart. The value is the index of the article, or empty for a redirect.
+func titleKey(title string) []byte {
+	return []byte(NormalizeTitle(title) + "\x00" + title)
+}
+
+// normalizedTitles returns the titles of the articles and redirects that
+// normalize to the same title as the title
+func normalizedTitles(titles *bolt.Bucket, title string) []string {
+	matches := make([]string, 0, 1)
+	if titles == nil {
+		return matches
+	}
+	prefix, cursor := []byte(NormalizeTitle(title)+"\x00"), titles.Cursor()
+	for key, _ := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = cursor.Next() {
+		matches = append(matches, string(key[len(prefix):]))
+	}
+	return matches
+}
+
+// resolveTitle follows the redirects of a title to the key of its article in
+// the wiki bucket, the title is the last title followed. The key is nil if the
+// title doesn't lead to an article.
+func resolveTitle(wiki, redirects *bolt.Bucket, title string) ([]byte, string) {
+	for i := 0; i <= MaxRedirects; i++ {
+		if key := wiki.Get([]byte(title)); key != nil {
+			return key, title
+		}
+		if redirects == nil {
+			return nil, title
+		}
+		target := redirects.Get([]byte(title))
+		if target == nil {
+			return nil, title
+		}
+		title, _ = SplitAnchor(string(target))
+	}
+	return nil, title
+}
+
+// AmbiguousError is the error of a lookup of a title that normalizes to the
+// same title as several articles
+type AmbiguousError struct {
+	Title string
+	// Titles are the titles of the articles
+	Titles []string
+}
+
+// Error returns the message of the error
+func (a *AmbiguousError) Error() string {
+	return fmt.Sprintf("%s is ambiguous: %s", a.Title, strings.Join(a.Titles, ", "))
+}
+
+// completion is a title of a completion
+type completion struct {
+	Title string
+	Rank  float32
+	// Order is the order of the title in the titles bucket, it breaks ties
+	Order int
+}
+
+// completionHeap is a min heap of completions, the top is the lowest ranked
+type completionHeap []completion
+
+// Len is the length of the heap
+func (c completionHeap) Len() int {
+	return len(c)
+}
+
+// Less is true if completion i is ranked lower than completion j
+func (c completionHeap) Less(i, j int) bool {
+	if c[i].Rank == c[j].Rank {
+		return c[i].Order > c[j].Order
+	}
+	return c[i].Rank < c[j].Rank
+}
+
+// Swap swaps two completions
+func (c completionHeap) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
+// Push pushes a completion onto the heap
+func (c *completionHeap) Push(x interface{}) {
+	*c = append(*c, x.(completion))
+}
+
+// Pop pops the last completion off of the heap
+func (c *completionHeap) Pop() interface{} {
+	old := *c
+	x := old[len(old)-1]
+	*c = old[:len(old)-1]
+	return x
+}
+
+// Complete returns the titles of the articles that start with the prefix once
+// normalized, the titles are ordered by page rank. At most n titles are
+// returned, DefaultCompletions if n is zero. The n highest ranked titles
+// are kept in a heap while at most MaxCompletionScan titles with the prefix
+// are read. An empty prefix completes nothing.
+func (e *Encyclopedia) Complete(prefix string, n int) ([]string, error) {
+	if n <= 0 {
+		n = DefaultCompletions
+	}
+	normalized := []byte(NormalizeTitle(prefix))
+	if len(normalized) == 0 {
+		return []string{}, nil
+	}
+	completions, order := make(completionHeap, 0, n), 0
+	err := e.DB.View(func(tx *bolt.Tx) error {
+		titles := tx.Bucket([]byte("titles"))
+		if titles == nil {
+			return nil
+		}
+		ranks := tx.Bucket([]byte("ranks"))
+		cursor, scanned := titles.Cursor(), 0
+		for key, value := cursor.Seek(normalized); key != nil && bytes.HasPrefix(key, normalized); key, value = cursor.Next() {
+			if scanned == MaxCompletionScan {
+				break
+			}
+			scanned++
+			if len(value) != 4 {
+				// a redirect
+				continue
+			}
+			c := completion{
+				Order: order,
+			}
+			order++
+			if ranks != nil {
+				if rank := ranks.Get(value); len(rank) > 0 {
+					c.Rank = math.Float32frombits(binary.LittleEndian.Uint32(rank))
+				}
+			}
+			if len(completions) == n {
+				if c.Rank <= completions[0].Rank {
+					continue
+				}
+				c.Title = string(key[bytes.IndexByte(key, 0)+1:])
+				completions[0] = c
+				heap.Fix(&completions, 0)
+				continue
+			}
+			c.Title = string(key[bytes.IndexByte(key, 0)+1:])
+			heap.Push(&completions, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(sort.Reverse(completions))
+	titles := make([]string, len(completions))
+	for i, completion := range completions {
+		titles[i] = completion.Title
+	}
+	return titles, nil
+}
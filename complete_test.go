@@ -0,0 +1,76 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/boltdb/bolt"
+)
+
+func TestComplete(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	test := func(prefix string, n int, expected ...string) {
+		titles, err := encyclopedia.Complete(prefix, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(titles) != fmt.Sprint(expected) {
+			t.Fatal("invalid completions", prefix, titles)
+		}
+	}
+	test("new", 0, "New York City")
+	test("NEW YORK C", 0, "New York City")
+	test("ZÜR", 0, "Zürich")
+	test("washington, d", 0, "Washington, D.C.")
+	test("Boston", 0)
+	test("t", 0, "Talk:United States", "Template:Navbox")
+
+	// the titles are ordered by page rank
+	rank := func(title string) (rank float32) {
+		err := encyclopedia.DB.View(func(tx *bolt.Tx) error {
+			index := tx.Bucket([]byte("wiki")).Get([]byte(title))
+			if value := tx.Bucket([]byte("ranks")).Get(index); len(value) == 4 {
+				rank = math.Float32frombits(binary.LittleEndian.Uint32(value))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rank
+	}
+	titles, err := encyclopedia.Complete("t", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(titles) != 2 || rank(titles[1]) > rank(titles[0]) {
+		t.Fatal("completions should be ordered by rank", titles)
+	}
+	// the completions are the highest ranked of every title with the prefix
+	top, err := encyclopedia.Complete("t", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(top) != fmt.Sprint(titles[:1]) {
+		t.Fatal("the top completions should not depend on n", top, titles)
+	}
+
+	// an empty prefix completes nothing
+	test("", 3)
+	test(" \t", 3)
+
+	// at most MaxCompletionScan titles are read
+	scan := MaxCompletionScan
+	defer func() {
+		MaxCompletionScan = scan
+	}()
+	MaxCompletionScan = 1
+	test("t", 2, "Talk:United States")
+}
@@ -0,0 +1,255 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// errStop stops the decoding of a dump
+var errStop = errors.New("stop decoding")
+
+// decode decodes the pages of a dump
+func decode(reader io.Reader, handle func(page Page) error) error {
+	decoder := xml.NewDecoder(reader)
+	token, err := decoder.Token()
+	for err == nil {
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Local == "page" {
+				var page Page
+				err := decoder.DecodeElement(&page, &element)
+				if err != nil {
+					return err
+				}
+				err = handle(page)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		token, err = decoder.Token()
+	}
+	if err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// Namespace is an entry of the siteinfo namespace table
+type Namespace struct {
+	Key  int32  `xml:"key,attr"`
+	Case string `xml:"case,attr"`
+	Name string `xml:",chardata"`
+}
+
+// Siteinfo is the siteinfo at the start of a dump
+type Siteinfo struct {
+	Sitename   string      `xml:"sitename"`
+	DBName     string      `xml:"dbname"`
+	Base       string      `xml:"base"`
+	Generator  string      `xml:"generator"`
+	Case       string      `xml:"case"`
+	Namespaces []Namespace `xml:"namespaces>namespace"`
+}
+
+// ReadSiteinfo reads the siteinfo at the start of a dump, the first stream of
+// a multistream dump holds the siteinfo
+func ReadSiteinfo(dump string) (*Siteinfo, error) {
+	input, err := os.Open(dump)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+	decoder := xml.NewDecoder(bzip2.NewReader(input))
+	token, err := decoder.Token()
+	for err == nil {
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "siteinfo":
+				siteinfo := Siteinfo{}
+				err := decoder.DecodeElement(&siteinfo, &element)
+				if err != nil {
+					return nil, err
+				}
+				return &siteinfo, nil
+			case "page":
+				return &Siteinfo{}, nil
+			}
+		}
+		token, err = decoder.Token()
+	}
+	if err == io.EOF {
+		return &Siteinfo{}, nil
+	}
+	return nil, err
+}
+
+// readOffsets reads the stream offsets from a multistream index
+func readOffsets(index io.Reader) ([]int64, error) {
+	offsets, seen := make([]int64, 0, 8), make(map[int64]bool)
+	scanner := bufio.NewScanner(bzip2.NewReader(index))
+	for scanner.Scan() {
+		line := scanner.Text()
+		end := strings.IndexByte(line, ':')
+		if end < 0 {
+			return nil, fmt.Errorf("invalid index line: %s", line)
+		}
+		offset, err := strconv.ParseInt(line[:end], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[offset] {
+			seen[offset] = true
+			offsets = append(offsets, offset)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(offsets, func(i, j int) bool {
+		return offsets[i] < offsets[j]
+	})
+	return offsets, nil
+}
+
+// block is a decoded bzip2 stream of a multistream dump
+type block struct {
+	Offset, Size int64
+	Pages        []Page
+	Err          error
+}
+
+// multistream decodes the pages of a multistream dump in parallel, the pages
+// are handled in the order they appear in the dump
+func multistream(input *os.File, offsets []int64, handle func(page Page) error) error {
+	info, err := input.Stat()
+	if err != nil {
+		return err
+	}
+	if len(offsets) == 0 {
+		return nil
+	}
+
+	streams := make(chan chan block, NumCPU)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		defer close(streams)
+		for i, offset := range offsets {
+			end := info.Size()
+			if i+1 < len(offsets) {
+				end = offsets[i+1]
+			}
+			stream := make(chan block, 1)
+			select {
+			case streams <- stream:
+			case <-stop:
+				return
+			}
+			go func(offset, end int64, last bool) {
+				result := block{
+					Offset: offset,
+					Size:   end - offset,
+				}
+				// the stream is wrapped in a root element, the last stream
+				// already holds the closing element of the dump
+				readers := []io.Reader{
+					strings.NewReader("<mediawiki>"),
+					bzip2.NewReader(io.NewSectionReader(input, offset, end-offset)),
+				}
+				if !last {
+					readers = append(readers, strings.NewReader("</mediawiki>"))
+				}
+				result.Err = decode(io.MultiReader(readers...), func(page Page) error {
+					page.Offset = offset
+					result.Pages = append(result.Pages, page)
+					return nil
+				})
+				stream <- result
+			}(offset, end, i+1 == len(offsets))
+		}
+	}()
+
+	for stream := range streams {
+		result := <-stream
+		if result.Err != nil {
+			return fmt.Errorf("stream at offset %d: %v", result.Offset, result.Err)
+		}
+		for _, page := range result.Pages {
+			err := handle(page)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Pages decodes the pages of the dump selected by the options and sends them
+// to the pages channel, which is closed when the dump is exhausted. Decoding
+// starts after the page of the checkpoint if it is set, and closing stop ends
+// the decoding early.
+func Pages(options Options, checkpoint Checkpoint, pages chan<- Page, stop <-chan struct{}) error {
+	defer close(pages)
+	skip := checkpoint.Page != 0
+	handle := func(page Page) error {
+		if skip {
+			skip = page.ID != checkpoint.Page
+			return nil
+		}
+		select {
+		case pages <- page:
+			return nil
+		case <-stop:
+			return errStop
+		}
+	}
+
+	input, err := os.Open(options.Dump)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	if options.Index == "" {
+		err = decode(bzip2.NewReader(input), handle)
+	} else {
+		var index *os.File
+		index, err = os.Open(options.Index)
+		if err != nil {
+			return err
+		}
+		var offsets []int64
+		offsets, err = readOffsets(index)
+		index.Close()
+		if err != nil {
+			return err
+		}
+		if skip {
+			i := sort.Search(len(offsets), func(i int) bool {
+				return offsets[i] >= checkpoint.Offset
+			})
+			offsets = offsets[i:]
+		}
+		err = multistream(input, offsets, handle)
+	}
+	if err == errStop {
+		return nil
+	} else if err == nil && skip {
+		return fmt.Errorf("page %d of the checkpoint is not in the dump", checkpoint.Page)
+	}
+	return err
+}
@@ -0,0 +1,750 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/boltdb/bolt"
+)
+
+const (
+	// TemplateNamespace is the namespace of the templates
+	TemplateNamespace = 10
+	// MaxExpansionDepth is the maximum depth of nested template expansions
+	MaxExpansionDepth = 40
+	// MaxExpansionNodes is the maximum number of segments expanded by an
+	// expansion
+	MaxExpansionNodes = 1000000
+	// MaxExpansionSize is the maximum size in bytes of the expanded templates
+	// of an expansion
+	MaxExpansionSize = 2 << 20
+)
+
+var (
+	// commentRegex matches an html comment
+	commentRegex = regexp.MustCompile(`(?s)<!--.*?(-->|$)`)
+	// onlyincludeRegex matches the part of a template that is transcluded
+	onlyincludeRegex = regexp.MustCompile(`(?is)<onlyinclude\s*>(.*?)</onlyinclude\s*>`)
+	// noincludeRegex matches the part of a template that isn't transcluded
+	noincludeRegex = regexp.MustCompile(`(?is)<noinclude\s*>.*?(</noinclude\s*>|$)`)
+	// includeonlyRegex matches the part of a template that is only transcluded
+	includeonlyRegex = regexp.MustCompile(`(?is)<includeonly\s*>.*?(</includeonly\s*>|$)`)
+	// inclusionRegex matches the tags of the transcluded parts of a template
+	inclusionRegex = regexp.MustCompile(`(?i)</?(noinclude|onlyinclude|includeonly)\s*>`)
+)
+
+// segment kinds
+const (
+	segmentText = iota
+	segmentTemplate
+	segmentParameter
+)
+
+// segment is text, a template {{...}} or a template parameter {{{...}}} of
+// preprocessed wikitext, the parts of a template or a parameter are the
+// segments between its pipes
+type segment struct {
+	Kind  int
+	Text  string
+	Parts [][]segment
+}
+
+// preprocess splits wikitext into segments by matching braces like MediaWiki
+// does: a run of braces is closed by the braces of the next run of closing
+// braces, three of them make a parameter and two of them a template. The pipes
+// of links don't separate the parts of templates, and the braces that aren't
+// closed are text.
+func preprocess(text string) []segment {
+	type open struct {
+		Brace byte
+		Count int
+		Parts [][]segment
+	}
+	root := &open{Parts: make([][]segment, 1)}
+	stack := []*open{root}
+	add := func(s segment) {
+		if s.Kind == segmentText && s.Text == "" {
+			return
+		}
+		top := stack[len(stack)-1]
+		part := &top.Parts[len(top.Parts)-1]
+		if last := len(*part) - 1; s.Kind == segmentText && last >= 0 && (*part)[last].Kind == segmentText {
+			(*part)[last].Text += s.Text
+			return
+		}
+		*part = append(*part, s)
+	}
+	// flatten adds the parts of an element as text separated by pipes
+	flatten := func(element *open, prefix, suffix string) {
+		add(segment{Text: prefix})
+		for i, part := range element.Parts {
+			if i > 0 {
+				add(segment{Text: "|"})
+			}
+			for _, s := range part {
+				add(s)
+			}
+		}
+		add(segment{Text: suffix})
+	}
+	for i := 0; i < len(text); {
+		j := strings.IndexAny(text[i:], "{}[]|")
+		if j < 0 {
+			add(segment{Text: text[i:]})
+			break
+		} else if j > 0 {
+			add(segment{Text: text[i : i+j]})
+			i += j
+			continue
+		}
+		run := 1
+		for i+run < len(text) && text[i+run] == text[i] {
+			run++
+		}
+		top := stack[len(stack)-1]
+		switch c := text[i]; {
+		case c == '{' && run >= 2:
+			stack = append(stack, &open{Brace: '{', Count: run, Parts: make([][]segment, 1)})
+		case c == '[' && run >= 2:
+			add(segment{Text: text[i : i+run-2]})
+			stack = append(stack, &open{Brace: '[', Count: 2, Parts: make([][]segment, 1)})
+		case c == '|' && len(stack) > 1:
+			top.Parts, run = append(top.Parts, nil), 1
+		case c == ']' && run >= 2 && top.Brace == '[':
+			stack, run = stack[:len(stack)-1], 2
+			flatten(top, "[[", "]]")
+		case c == '}' && run >= 2 && top.Brace == '{':
+			n := run
+			for n >= 2 && stack[len(stack)-1].Brace == '{' {
+				top := stack[len(stack)-1]
+				matched := n
+				if top.Count < matched {
+					matched = top.Count
+				}
+				s := segment{Kind: segmentTemplate, Parts: top.Parts}
+				if matched >= 3 {
+					matched, s.Kind = 3, segmentParameter
+				}
+				top.Count, n = top.Count-matched, n-matched
+				if top.Count >= 2 {
+					top.Parts = [][]segment{{s}}
+					continue
+				}
+				stack = stack[:len(stack)-1]
+				if top.Count == 1 {
+					add(segment{Text: "{"})
+				}
+				add(s)
+			}
+			add(segment{Text: strings.Repeat("}", n)})
+		default:
+			add(segment{Text: text[i : i+run]})
+		}
+		i += run
+	}
+	for len(stack) > 1 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		flatten(top, strings.Repeat(string(top.Brace), top.Count), "")
+	}
+	return root.Parts[0]
+}
+
+// source returns the wikitext of segments
+func source(segments []segment) string {
+	var text strings.Builder
+	for _, s := range segments {
+		if s.Kind == segmentText {
+			text.WriteString(s.Text)
+			continue
+		}
+		braces := 2
+		if s.Kind == segmentParameter {
+			braces = 3
+		}
+		text.WriteString(strings.Repeat("{", braces))
+		for i, part := range s.Parts {
+			if i > 0 {
+				text.WriteString("|")
+			}
+			text.WriteString(source(part))
+		}
+		text.WriteString(strings.Repeat("}", braces))
+	}
+	return text.String()
+}
+
+// argument splits a part of a template at its first equals sign outside of
+// templates and parameters, named is false if it has none
+func argument(part []segment) (name, value []segment, named bool) {
+	for i, s := range part {
+		if s.Kind != segmentText {
+			continue
+		}
+		if j := strings.IndexByte(s.Text, '='); j >= 0 {
+			name = append(append(name, part[:i]...), segment{Text: s.Text[:j]})
+			value = append(append(value, segment{Text: s.Text[j+1:]}), part[i+1:]...)
+			return name, value, true
+		}
+	}
+	return nil, part, false
+}
+
+// templateName normalizes the name of a template like MediaWiki does:
+// underscores are spaces, runs of spaces are a space and the first letter is
+// upper case
+func templateName(name string) string {
+	name = strings.Join(strings.Fields(strings.Replace(name, "_", " ", -1)), " ")
+	r, size := utf8.DecodeRuneInString(name)
+	if size == 0 {
+		return name
+	}
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// transclude returns the part of the text of a template that is transcluded
+func transclude(text string) string {
+	text = commentRegex.ReplaceAllString(text, "")
+	if matches := onlyincludeRegex.FindAllStringSubmatch(text, -1); matches != nil {
+		parts := make([]string, len(matches))
+		for i, match := range matches {
+			parts[i] = match[1]
+		}
+		text = strings.Join(parts, "")
+	}
+	text = noincludeRegex.ReplaceAllString(text, "")
+	return inclusionRegex.ReplaceAllString(text, "")
+}
+
+// Expand expands the templates, the template parameters and the parser
+// functions #if, #ifeq, #switch and #expr of wikitext. The text of a template
+// is looked up by its name, the templates that aren't found and the unknown
+// parser functions are left as they are. The parameters that aren't given are
+// replaced by their defaults. Nested templates are expanded up to
+// MaxExpansionDepth and template loops are reported in the text. Like
+// MediaWiki the work is limited: the templates aren't expanded once
+// MaxExpansionNodes segments have been expanded or the expanded templates are
+// larger than MaxExpansionSize, which is reported in the text.
+func Expand(text string, lookup func(name string) (string, bool, error)) (string, error) {
+	type frame struct {
+		Name      string
+		Arguments map[string]string
+		Parent    *frame
+		Depth     int
+	}
+	type body struct {
+		Segments []segment
+		Has      bool
+	}
+	nodes, size, bodies := 0, 0, make(map[string]body)
+	var expand func(segments []segment, f *frame) (string, error)
+	// arguments expands the named and the positional arguments of a template
+	arguments := func(parts [][]segment, f *frame) (map[string]string, error) {
+		args, position := make(map[string]string), 1
+		for _, part := range parts {
+			name, value, named := argument(part)
+			v, err := expand(value, f)
+			if err != nil {
+				return nil, err
+			}
+			if !named {
+				args[strconv.Itoa(position)] = v
+				position++
+				continue
+			}
+			n, err := expand(name, f)
+			if err != nil {
+				return nil, err
+			}
+			args[strings.TrimSpace(n)] = strings.TrimSpace(v)
+		}
+		return args, nil
+	}
+	expand = func(segments []segment, f *frame) (string, error) {
+		var text strings.Builder
+		for _, s := range segments {
+			nodes++
+			switch s.Kind {
+			case segmentText:
+				text.WriteString(s.Text)
+			case segmentParameter:
+				name, err := expand(s.Parts[0], f)
+				if err != nil {
+					return "", err
+				}
+				name = strings.TrimSpace(name)
+				if value, has := f.Arguments[name]; has {
+					text.WriteString(value)
+				} else if len(s.Parts) > 1 {
+					value, err := expand(s.Parts[1], f)
+					if err != nil {
+						return "", err
+					}
+					text.WriteString(value)
+				} else {
+					text.WriteString("{{{" + name + "}}}")
+				}
+			case segmentTemplate:
+				name, err := expand(s.Parts[0], f)
+				if err != nil {
+					return "", err
+				}
+				name = strings.TrimSpace(name)
+				if strings.HasPrefix(name, "#") {
+					value, has := "", false
+					if i := strings.IndexByte(name, ':'); i >= 0 {
+						function := strings.ToLower(strings.TrimSpace(name[1:i]))
+						value, has, err = parserFunction(function, strings.TrimSpace(name[i+1:]), s.Parts[1:],
+							func(part []segment) (string, error) {
+								return expand(part, f)
+							})
+						if err != nil {
+							return "", err
+						}
+					}
+					if !has {
+						value = source([]segment{s})
+					}
+					text.WriteString(value)
+					continue
+				}
+				name = templateName(name)
+				if f.Depth >= MaxExpansionDepth {
+					text.WriteString(`<span class="error">Expansion depth limit exceeded</span>`)
+					continue
+				} else if nodes > MaxExpansionNodes {
+					text.WriteString(`<span class="error">Node count limit exceeded</span>`)
+					continue
+				} else if size > MaxExpansionSize {
+					text.WriteString(`<span class="error">Template include size limit exceeded</span>`)
+					continue
+				}
+				loop := false
+				for parent := f; parent != nil; parent = parent.Parent {
+					if parent.Name == name {
+						loop = true
+					}
+				}
+				if loop {
+					text.WriteString(fmt.Sprintf(`<span class="error">Template loop detected: [[Template:%s]]</span>`, name))
+					continue
+				}
+				b, cached := bodies[name]
+				if !cached {
+					text, has, err := lookup(name)
+					if err != nil {
+						return "", err
+					}
+					b = body{Has: has}
+					if has {
+						b.Segments = preprocess(transclude(text))
+					}
+					bodies[name] = b
+				}
+				if !b.Has {
+					text.WriteString(source([]segment{s}))
+					continue
+				}
+				args, err := arguments(s.Parts[1:], f)
+				if err != nil {
+					return "", err
+				}
+				value, err := expand(b.Segments, &frame{
+					Name:      name,
+					Arguments: args,
+					Parent:    f,
+					Depth:     f.Depth + 1,
+				})
+				if err != nil {
+					return "", err
+				}
+				if size += len(value); size > MaxExpansionSize {
+					text.WriteString(`<span class="error">Template include size limit exceeded</span>`)
+					continue
+				}
+				text.WriteString(value)
+			}
+		}
+		return text.String(), nil
+	}
+	text = includeonlyRegex.ReplaceAllString(text, "")
+	text = inclusionRegex.ReplaceAllString(text, "")
+	return expand(preprocess(text), &frame{})
+}
+
+// equal compares the values of #ifeq and #switch numerically if they are both
+// numbers
+func equal(a, b string) bool {
+	x, err := strconv.ParseFloat(a, 64)
+	if err != nil {
+		return a == b
+	}
+	y, err := strconv.ParseFloat(b, 64)
+	if err != nil {
+		return a == b
+	}
+	return x == y
+}
+
+// parserFunction evaluates a parser function, the first argument is after the
+// colon and the branches are only expanded if they are taken. has is false if
+// the function is unknown.
+func parserFunction(function, first string, parts [][]segment, expand func(part []segment) (string, error)) (value string, has bool, err error) {
+	// branch expands the trimmed nth part
+	branch := func(n int) (string, bool, error) {
+		if n >= len(parts) {
+			return "", true, nil
+		}
+		value, err := expand(parts[n])
+		return strings.TrimSpace(value), true, err
+	}
+	switch function {
+	case "if":
+		if first != "" {
+			return branch(0)
+		}
+		return branch(1)
+	case "ifeq":
+		second := ""
+		if len(parts) > 0 {
+			second, err = expand(parts[0])
+			if err != nil {
+				return "", true, err
+			}
+		}
+		if equal(first, strings.TrimSpace(second)) {
+			return branch(1)
+		}
+		return branch(2)
+	case "switch":
+		found, fallback := false, ""
+		for i, part := range parts {
+			name, v, named := argument(part)
+			if !named {
+				key, err := expand(part)
+				if err != nil {
+					return "", true, err
+				}
+				key = strings.TrimSpace(key)
+				if equal(key, first) {
+					found = true
+				} else if i == len(parts)-1 {
+					fallback = key
+				}
+				continue
+			}
+			key, err := expand(name)
+			if err != nil {
+				return "", true, err
+			}
+			key = strings.TrimSpace(key)
+			if found || equal(key, first) {
+				value, err := expand(v)
+				return strings.TrimSpace(value), true, err
+			}
+			if key == "#default" {
+				fallback, err = expand(v)
+				if err != nil {
+					return "", true, err
+				}
+				fallback = strings.TrimSpace(fallback)
+			}
+		}
+		return fallback, true, nil
+	case "expr":
+		if first == "" {
+			return "", true, nil
+		}
+		result, err := Evaluate(first)
+		if err != nil {
+			return fmt.Sprintf(`<strong class="error">Expression error: %s</strong>`, err), true, nil
+		}
+		return FormatNumber(result), true, nil
+	}
+	return "", false, nil
+}
+
+// FormatNumber formats the result of an expression like #expr, whole numbers
+// are integers
+func FormatNumber(value float64) string {
+	if value == math.Trunc(value) && math.Abs(value) < 1e15 {
+		return strconv.FormatInt(int64(value), 10)
+	}
+	return strconv.FormatFloat(value, 'G', 14, 64)
+}
+
+// precedences are the precedences of the binary operators of expressions
+var precedences = map[string]int{
+	"or":    2,
+	"and":   3,
+	"=":     4,
+	"<>":    4,
+	"!=":    4,
+	"<":     4,
+	">":     4,
+	"<=":    4,
+	">=":    4,
+	"round": 5,
+	"+":     6,
+	"-":     6,
+	"*":     7,
+	"/":     7,
+	"div":   7,
+	"mod":   7,
+	"fmod":  7,
+	"^":     8,
+	"e":     10,
+}
+
+// functions are the unary functions of expressions
+var functions = map[string]func(float64) float64{
+	"not": func(x float64) float64 {
+		if x == 0 {
+			return 1
+		}
+		return 0
+	},
+	"abs":   math.Abs,
+	"floor": math.Floor,
+	"ceil":  math.Ceil,
+	"trunc": math.Trunc,
+	"sqrt":  math.Sqrt,
+	"ln":    math.Log,
+	"exp":   math.Exp,
+}
+
+// Evaluate evaluates an expression of #expr: numbers, pi and e, the unary
+// operators +, -, not, abs, floor, ceil, trunc, sqrt, ln and exp, and the
+// binary operators ^, *, /, div, mod, fmod, +, -, round, =, <>, !=, <, >, <=,
+// >=, and and or with the precedences of MediaWiki
+func Evaluate(expression string) (float64, error) {
+	tokens, expression := make([]string, 0, 8), strings.ToLower(expression)
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c >= '0' && c <= '9' || c == '.':
+			j := i
+			for j < len(expression) && (expression[j] >= '0' && expression[j] <= '9' || expression[j] == '.') {
+				j++
+			}
+			tokens, i = append(tokens, expression[i:j]), j
+		case c >= 'a' && c <= 'z':
+			j := i
+			for j < len(expression) && expression[j] >= 'a' && expression[j] <= 'z' {
+				j++
+			}
+			tokens, i = append(tokens, expression[i:j]), j
+		case strings.HasPrefix(expression[i:], "<=") || strings.HasPrefix(expression[i:], ">=") ||
+			strings.HasPrefix(expression[i:], "<>") || strings.HasPrefix(expression[i:], "!="):
+			tokens, i = append(tokens, expression[i:i+2]), i+2
+		case strings.IndexByte("+-*/^()=<>", c) >= 0:
+			tokens, i = append(tokens, expression[i:i+1]), i+1
+		default:
+			return 0, fmt.Errorf("unrecognized punctuation character %q", c)
+		}
+	}
+
+	position := 0
+	truth := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+	var binary func(precedence int) (float64, error)
+	unary := func() (float64, error) {
+		if position >= len(tokens) {
+			return 0, fmt.Errorf("missing operand")
+		}
+		token := tokens[position]
+		position++
+		switch {
+		case token == "(":
+			value, err := binary(0)
+			if err != nil {
+				return 0, err
+			}
+			if position >= len(tokens) || tokens[position] != ")" {
+				return 0, fmt.Errorf("unexpected opening bracket")
+			}
+			position++
+			return value, nil
+		case token == "-" || token == "+":
+			value, err := binary(10)
+			if token == "-" {
+				value = -value
+			}
+			return value, err
+		case functions[token] != nil:
+			value, err := binary(9)
+			return functions[token](value), err
+		case token == "pi":
+			return math.Pi, nil
+		case token == "e":
+			return math.E, nil
+		case token[0] >= '0' && token[0] <= '9' || token[0] == '.':
+			value, err := strconv.ParseFloat(token, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid number %s", token)
+			}
+			return value, nil
+		}
+		if _, has := precedences[token]; has || token == ")" {
+			return 0, fmt.Errorf("missing operand for %s", token)
+		}
+		return 0, fmt.Errorf("unrecognized word %q", token)
+	}
+	binary = func(precedence int) (float64, error) {
+		left, err := unary()
+		if err != nil {
+			return 0, err
+		}
+		for position < len(tokens) {
+			operator := tokens[position]
+			p, has := precedences[operator]
+			if !has || p < precedence {
+				break
+			}
+			position++
+			right, err := binary(p + 1)
+			if err != nil {
+				return 0, err
+			}
+			switch operator {
+			case "or":
+				left = truth(left != 0 || right != 0)
+			case "and":
+				left = truth(left != 0 && right != 0)
+			case "=":
+				left = truth(left == right)
+			case "<>", "!=":
+				left = truth(left != right)
+			case "<":
+				left = truth(left < right)
+			case ">":
+				left = truth(left > right)
+			case "<=":
+				left = truth(left <= right)
+			case ">=":
+				left = truth(left >= right)
+			case "round":
+				scale := math.Pow(10, math.Trunc(right))
+				left = math.Round(left*scale) / scale
+			case "+":
+				left += right
+			case "-":
+				left -= right
+			case "*":
+				left *= right
+			case "/", "div":
+				if right == 0 {
+					return 0, fmt.Errorf("division by zero")
+				}
+				left /= right
+			case "mod":
+				if int64(right) == 0 {
+					return 0, fmt.Errorf("division by zero")
+				}
+				left = float64(int64(left) % int64(right))
+			case "fmod":
+				if right == 0 {
+					return 0, fmt.Errorf("division by zero")
+				}
+				left = math.Mod(left, right)
+			case "^":
+				left = math.Pow(left, right)
+			case "e":
+				left *= math.Pow(10, right)
+			}
+		}
+		return left, nil
+	}
+	value, err := binary(0)
+	if err != nil {
+		return 0, err
+	}
+	if position < len(tokens) {
+		return 0, fmt.Errorf("unexpected %s", tokens[position])
+	}
+	return value, nil
+}
+
+// templateKey is the key of a template page in the templates bucket, the
+// namespace of its title is removed
+func templateKey(title string) []byte {
+	if i := strings.IndexByte(title, ':'); i >= 0 {
+		title = title[i+1:]
+	}
+	return []byte(templateName(title))
+}
+
+// putTemplate puts a template page into the templates bucket
+func putTemplate(templates *bolt.Bucket, page Page) error {
+	value, err := encodeArticle(&Article{
+		Title:     page.Title,
+		ID:        page.ID,
+		Text:      page.Text,
+		Namespace: page.Namespace,
+		Revision:  page.Revision,
+	})
+	if err != nil {
+		return err
+	}
+	return templates.Put(templateKey(page.Title), value)
+}
+
+// Expand expands the templates of wikitext with the template pages of the db,
+// the redirects of the templates are followed
+func (e *Encyclopedia) Expand(text string) (expanded string, err error) {
+	err = e.DB.View(func(tx *bolt.Tx) error {
+		templates, prefixes := tx.Bucket([]byte("templates")), []string{"Template"}
+		if namespaces := tx.Bucket([]byte("namespaces")); namespaces != nil {
+			key := make([]byte, 4)
+			binary.LittleEndian.PutUint32(key, TemplateNamespace)
+			if name := namespaces.Get(key); name != nil {
+				prefixes = append(prefixes, string(name))
+			}
+		}
+		lookup := func(name string) (string, bool, error) {
+			if templates == nil {
+				return "", false, nil
+			}
+			for i := 0; i <= MaxRedirects; i++ {
+				if j := strings.IndexByte(name, ':'); j >= 0 {
+					for _, prefix := range prefixes {
+						if strings.EqualFold(strings.TrimSpace(name[:j]), prefix) {
+							name = name[j+1:]
+							break
+						}
+					}
+				}
+				template, err := getArticle(templates, []byte(templateName(name)))
+				if err != nil || template == nil {
+					return "", false, err
+				}
+				if matches := RedirectRegex.FindStringSubmatch(template.Text); matches != nil {
+					name, _ = SplitAnchor(matches[1])
+					continue
+				}
+				return template.Text, true, nil
+			}
+			return "", false, fmt.Errorf("too many redirects for template %s", name)
+		}
+		var err error
+		expanded, err = Expand(text, lookup)
+		return err
+	})
+	return expanded, err
+}
@@ -0,0 +1,198 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPreprocess(t *testing.T) {
+	test := func(text, target string) {
+		if s := source(preprocess(text)); s != target {
+			t.Fatalf("not equal %q %q", text, s)
+		}
+	}
+	for _, text := range []string{
+		"plain text",
+		"{{a|b|c=d}}",
+		"{{{1|{{b}}}}}",
+		"{{a|[[b|c]]}}",
+		"{{{{a}}}}",
+		"{{a}}}",
+		"{{{a}}",
+		"{{ unclosed | x",
+		"[[a|{{b}}]] ]] }} [",
+	} {
+		test(text, text)
+	}
+
+	segments := preprocess("x{{a|[[b|c]]|d={{e}}}}")
+	if len(segments) != 2 || segments[1].Kind != segmentTemplate || len(segments[1].Parts) != 3 {
+		t.Fatal("invalid segments", segments)
+	}
+	segments = preprocess("{{{{{a}}}}}")
+	if len(segments) != 1 || segments[0].Kind != segmentTemplate ||
+		segments[0].Parts[0][0].Kind != segmentParameter {
+		t.Fatal("invalid segments", segments)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	test := func(expression, target string) {
+		value, err := Evaluate(expression)
+		if err != nil {
+			t.Fatal(expression, err)
+		}
+		if result := FormatNumber(value); result != target {
+			t.Fatalf("not equal %q %q", expression, result)
+		}
+	}
+	test("1 + 2 * 3", "7")
+	test("(1 + 2) * 3", "9")
+	test("-2 ^ 2", "4")
+	test("2 ^ 3 ^ 2", "64")
+	test("7 mod 3", "1")
+	test("7 div 2", "3.5")
+	test("1 / 3", "0.33333333333333")
+	test("2e3", "2000")
+	test("3.14159 round 2", "3.14")
+	test("1 < 2 and 2 <= 2", "1")
+	test("not 1 or 0", "0")
+	test("abs -3 + floor 2.5", "5")
+	test("2 = 2.0", "1")
+	test("pi > 3", "1")
+
+	for _, expression := range []string{"1 / 0", "1 +", "2 foo", "(1", "1 $ 2"} {
+		if _, err := Evaluate(expression); err == nil {
+			t.Fatal("should be an error", expression)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	templates := map[string]string{
+		"Greeting":  "Hello {{{1|world}}}{{{punctuation|!}}}",
+		"Infobox":   "<onlyinclude>{{#if:{{{name|}}}|'''{{{name}}}''' is a {{{type|thing}}}}}</onlyinclude> documentation",
+		"Doc":       "used<noinclude> documentation</noinclude><includeonly> included</includeonly><!-- {{Loop}} -->",
+		"Loop":      "{{Loop}}",
+		"Nest":      "{{Greeting|{{{1}}}}}",
+		"Size":      "{{#switch:{{{1}}}|small|tiny=S|large=L|#default=M}}",
+		"Same":      "{{#ifeq:{{{1}}}|{{{2}}}|same|different}}",
+		"Arguments": "{{{1}}},{{{2}}},{{{a}}}",
+	}
+	lookup := func(name string) (string, bool, error) {
+		text, has := templates[name]
+		return text, has, nil
+	}
+	test := func(text, target string) {
+		expanded, err := Expand(text, lookup)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expanded != target {
+			t.Fatalf("not equal %q %q", text, expanded)
+		}
+	}
+	test("{{Greeting}}", "Hello world!")
+	test("{{greeting|you|punctuation=?}}", "Hello you?")
+	test("{{ Greeting | 1 = x }}", "Hello x!")
+	test("{{Nest|again}}", "Hello again!")
+	test("{{Arguments| a | b |a= c }}", " a , b ,c")
+	test("{{Infobox|name=Paris|type=city}}", "'''Paris''' is a city")
+	test("{{Infobox}}", "")
+	test("{{Doc}}", "used included")
+	test("Doc<noinclude>!</noinclude><includeonly>?</includeonly>", "Doc!")
+	test("{{Size|tiny}} {{Size|large}} {{Size|medium}}", "S L M")
+	test("{{Same|1|01}} {{Same|a|b}}", "same different")
+	test("{{#if: | yes | no }} {{#if:x|yes}}", "no yes")
+	test("{{#switch: b | a = 1 | b = 2 }} {{#switch:c|a=1|3}}", "2 3")
+	test("{{#expr: 2 * (3 + 4) }} {{#expr: 1/0}}", "14 <strong class=\"error\">Expression error: division by zero</strong>")
+	test("{{#invoke:Module|main}} {{Missing|x}} {{{1|default}}} {{{1}}}", "{{#invoke:Module|main}} {{Missing|x}} default {{{1}}}")
+	test("{{Loop}}", "<span class=\"error\">Template loop detected: [[Template:Loop]]</span>")
+
+	templates["Deep"] = "{{Deep2}}"
+	for i := 2; i < MaxExpansionDepth+2; i++ {
+		templates["Deep"+strconv.Itoa(i)] = "{{Deep" + strconv.Itoa(i+1) + "}}"
+	}
+	expanded, err := Expand("{{Deep}}", lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(expanded, "Expansion depth limit exceeded") {
+		t.Fatal("the depth should be limited", expanded)
+	}
+}
+
+func TestExpandLimits(t *testing.T) {
+	// each template includes the next one twice, so expanding the first one
+	// expands 2^(MaxExpansionDepth-1) templates without the limits even though
+	// the text is empty
+	templates := make(map[string]string)
+	for i := 0; i < MaxExpansionDepth-1; i++ {
+		next := "{{T" + strconv.Itoa(i+1) + "}}"
+		templates["T"+strconv.Itoa(i)] = next + next
+	}
+	templates["T"+strconv.Itoa(MaxExpansionDepth-1)] = ""
+	lookup := func(name string) (string, bool, error) {
+		text, has := templates[name]
+		return text, has, nil
+	}
+	expanded, err := Expand("{{T0}}", lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(expanded, "Node count limit exceeded") {
+		t.Fatal("the nodes should be limited", len(expanded))
+	}
+
+	// each template includes the next one twice and a large text
+	large := strings.Repeat("x", 1<<16)
+	for i := 0; i < MaxExpansionDepth-1; i++ {
+		next := "{{T" + strconv.Itoa(i+1) + "}}"
+		templates["T"+strconv.Itoa(i)] = next + large + next
+	}
+	expanded, err = Expand("{{T0}}", lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(expanded, "Template include size limit exceeded") || len(expanded) > 2*MaxExpansionSize {
+		t.Fatal("the size should be limited", len(expanded))
+	}
+}
+
+func TestEncyclopediaExpand(t *testing.T) {
+	included, cleanup := testBuild(t, func(dir string) Options {
+		options := testOptions(dir)
+		options.Include = []int32{0}
+		return options
+	})
+	defer cleanup()
+	expanded, err := included.Expand("{{navbox|title=Cities}} {{Template:Navbox|title=Towns}} {{Missing}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := "<div class=\"navbox\">Cities discussion</div> <div class=\"navbox\">Towns discussion</div> {{Missing}}"
+	if expanded != target {
+		t.Fatalf("not equal %q", expanded)
+	}
+	for _, text := range []string{"{{Infobox settlement|name=X}}\n", "{{#if:||}}", "{{Infobox|name=X|empty={{#if:||}}}}"} {
+		html, err := included.Render(&Article{Title: "X", Text: text})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(html, "{{") {
+			t.Fatal("the templates should be rendered", html)
+		}
+	}
+	article, err := included.Lookup("Template:Navbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if article != nil {
+		t.Fatal("the template namespace shouldn't be included")
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"sort"
+	"strings"
+)
+
+// quote is the formatting of a run of apostrophes, the literal apostrophes
+// of the run are followed by html tags
+type quote struct {
+	Literal int
+	Tags    string
+}
+
+// closing are the tags closed at the end of a line, Position is the position
+// of the end of the line
+type closing struct {
+	Position uint32
+	Tags     string
+}
+
+// apostrophes formats the runs of apostrophes of a line like MediaWiki does: a
+// run of 2 is italic, 3 is bold and 5 is both. The first apostrophe of a run of
+// 4 and the apostrophes of a run longer than 5 are literal. If both the italics
+// and the bolds of the line are unbalanced, the first bold after a single
+// letter word, else after a word, else after a space is an apostrophe and an
+// italic. The tags that are open at the end of the line are closed.
+func apostrophes(line []rune, runs [][2]int) ([]quote, string) {
+	quotes, lengths := make([]quote, len(runs)), make([]int, len(runs))
+	italics, bolds := 0, 0
+	for i, run := range runs {
+		n := run[1] - run[0]
+		switch {
+		case n == 4:
+			quotes[i].Literal, n = 1, 3
+		case n > 5:
+			quotes[i].Literal, n = n-5, 5
+		}
+		switch n {
+		case 2:
+			italics++
+		case 3:
+			bolds++
+		case 5:
+			italics++
+			bolds++
+		}
+		lengths[i] = n
+	}
+	if italics%2 == 1 && bolds%2 == 1 {
+		singleLetter, multiLetter, space := -1, -1, -1
+		for i, n := range lengths {
+			if n != 3 {
+				continue
+			}
+			before := func(j int) rune {
+				if j := runs[i][0] + quotes[i].Literal - j; j >= 0 {
+					return line[j]
+				}
+				return 0
+			}
+			if before(1) == ' ' {
+				if space == -1 {
+					space = i
+				}
+			} else if before(2) == ' ' {
+				singleLetter = i
+				break
+			} else if multiLetter == -1 {
+				multiLetter = i
+			}
+		}
+		for _, i := range []int{singleLetter, multiLetter, space} {
+			if i >= 0 {
+				quotes[i].Literal++
+				lengths[i] = 2
+				break
+			}
+		}
+	}
+
+	state := ""
+	for i, n := range lengths {
+		tags := ""
+		switch n {
+		case 2:
+			switch state {
+			case "i":
+				tags, state = "</i>", ""
+			case "bi":
+				tags, state = "</i>", "b"
+			case "ib":
+				tags, state = "</b></i><b>", "b"
+			case "both":
+				tags, state = "</i>", "b"
+			default:
+				tags, state = "<i>", state+"i"
+			}
+		case 3:
+			switch state {
+			case "b":
+				tags, state = "</b>", ""
+			case "bi":
+				tags, state = "</i></b><i>", "i"
+			case "ib":
+				tags, state = "</b>", "i"
+			case "both":
+				tags, state = "</b>", "i"
+			default:
+				tags, state = "<b>", state+"b"
+			}
+		case 5:
+			switch state {
+			case "b":
+				tags, state = "</b><i>", "i"
+			case "i":
+				tags, state = "</i><b>", "b"
+			case "bi":
+				tags, state = "</i></b>", ""
+			case "ib":
+				tags, state = "</b></i>", ""
+			case "both":
+				tags, state = "</b></i>", ""
+			default:
+				// the order of the tags depends on which is closed first
+				tags, state = "<b><i>", "both"
+				if i+1 < len(lengths) && lengths[i+1] != 2 {
+					tags = "<i><b>"
+				}
+			}
+		}
+		quotes[i].Tags = tags
+	}
+	closed := ""
+	switch state {
+	case "b":
+		closed = "</b>"
+	case "i":
+		closed = "</i>"
+	case "bi":
+		closed = "</i></b>"
+	case "ib":
+		closed = "</b></i>"
+	case "both":
+		closed = "</i></b>"
+	}
+	return quotes, closed
+}
+
+// quotes formats the runs of apostrophes of the lines of a parsed wikitext,
+// the formatting of each run is keyed by its position and the tags closed at
+// the end of the lines are in the order of their positions. The content of a table
+// cell bounds its lines.
+func quotes(parser *Wikipedia) (map[uint32]quote, []closing) {
+	type Line struct {
+		Start, End uint32
+		Runs       [][2]int
+	}
+	lines, buffer := make([]*Line, 0, 8), parser.buffer
+	var walk func(node *node32, lower, upper uint32)
+	walk = func(node *node32, lower, upper uint32) {
+		for ; node != nil; node = node.next {
+			switch node.pegRule {
+			case rulecell_content, ruleheader_content:
+				walk(node.up, node.begin, node.end)
+				continue
+			case rulequote:
+			default:
+				walk(node.up, lower, upper)
+				continue
+			}
+			start, end := node.begin, node.end
+			for start > lower && buffer[start-1] != '\n' {
+				start--
+			}
+			for end < upper && buffer[end] != '\n' {
+				end++
+			}
+			if end > node.end && buffer[end-1] == '\r' {
+				end--
+			}
+			if last := len(lines) - 1; last < 0 || lines[last].Start != start || lines[last].End != end {
+				lines = append(lines, &Line{Start: start, End: end})
+			}
+			line := lines[len(lines)-1]
+			line.Runs = append(line.Runs, [2]int{int(node.begin - line.Start), int(node.end - line.Start)})
+		}
+	}
+	walk(parser.AST(), 0, uint32(len(buffer)))
+
+	formatted, closings := make(map[uint32]quote), make([]closing, 0, len(lines))
+	for _, line := range lines {
+		runs, closed := apostrophes(buffer[line.Start:line.End], line.Runs)
+		for i, run := range runs {
+			formatted[line.Start+uint32(line.Runs[i][0])] = run
+		}
+		if closed != "" {
+			closings = append(closings, closing{Position: line.End, Tags: closed})
+		}
+	}
+	sort.SliceStable(closings, func(i, j int) bool {
+		return closings[i].Position < closings[j].Position
+	})
+	return formatted, closings
+}
+
+// formatTag returns the name of a formatting tag of a parsed wikitext and
+// whether it closes the tag, the name is empty for a self closing tag
+func formatTag(parser *Wikipedia, node *node32) (string, bool) {
+	closes, name := false, ""
+	for node := node.up; node != nil; node = node.next {
+		switch node.pegRule {
+		case ruleformat_close:
+			closes = true
+		case ruleformat_name:
+			name = strings.ToLower(string(parser.buffer[node.begin:node.end]))
+		}
+	}
+	if parser.buffer[node.end-2] == '/' {
+		return "", closes
+	}
+	return name, closes
+}
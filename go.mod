@@ -8,5 +8,8 @@ require (
 	github.com/julienschmidt/httprouter v1.3.0
 	github.com/pointlander/compress v1.1.1-0.20210112171536-f1390ed9e1af
 	github.com/pointlander/pagerank v0.0.0-20210619221740-830548a59275
+	golang.org/x/text v0.3.5
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
+	google.golang.org/grpc v1.36.0
 	google.golang.org/protobuf v1.25.0
 )
@@ -0,0 +1,158 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// service is the grpc service of an encyclopedia
+type service struct {
+	UnimplementedEncyclopediaServer
+	encyclopedia *Encyclopedia
+}
+
+// GRPCServer registers the grpc service of the encyclopedia with a grpc server
+func GRPCServer(encyclopedia *Encyclopedia, server *grpc.Server) {
+	RegisterEncyclopediaServer(server, &service{encyclopedia: encyclopedia})
+}
+
+// grpcError converts an error of the encyclopedia into a grpc status, the
+// titles of an ambiguous title are violations in the details
+func grpcError(err error) error {
+	switch e := err.(type) {
+	case *QueryError:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case *AmbiguousError:
+		failure := &errdetails.PreconditionFailure{}
+		for _, title := range e.Titles {
+			failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+				Type:        "AMBIGUOUS",
+				Subject:     title,
+				Description: "the title normalizes to the same title as " + e.Title,
+			})
+		}
+		s, err := status.New(codes.FailedPrecondition, err.Error()).WithDetails(failure)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return s.Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// Lookup looks up an article, redirects are followed
+func (s *service) Lookup(ctx context.Context, request *LookupRequest) (*Article, error) {
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing article title")
+	}
+	article, err := s.encyclopedia.Lookup(title)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if article == nil {
+		return nil, status.Error(codes.NotFound, "article not found: "+title)
+	}
+	return article, nil
+}
+
+// Search streams the summary and then a page of the results of a query
+func (s *service) Search(request *SearchQuery, stream Encyclopedia_SearchServer) error {
+	if strings.TrimSpace(request.Query) == "" {
+		return status.Error(codes.InvalidArgument, "missing query")
+	}
+	limit := int(request.Limit)
+	if limit > MaxPerPage {
+		limit = MaxPerPage
+	}
+	results, err := s.encyclopedia.Search(SearchRequest{
+		Query:      request.Query,
+		Namespaces: request.Namespaces,
+		Offset:     int(request.Offset),
+		Limit:      limit,
+		Candidates: int(request.Candidates),
+	})
+	if err != nil {
+		return grpcError(err)
+	}
+	err = stream.Send(&SearchResponse{
+		Response: &SearchResponse_Summary{
+			Summary: &SearchSummary{
+				Total:      uint32(results.Total),
+				Scored:     uint32(results.Scored),
+				Suggestion: results.Suggestion,
+				Corrected:  results.Corrected,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, result := range results.Results {
+		r := SearchResult{
+			Title:     result.Article.Title,
+			Namespace: result.Article.Namespace,
+			Score:     result.Score,
+			Rank:      result.Rank,
+			Count:     uint32(result.Count),
+			Matches:   uint32(result.Matches),
+			Snippets:  make([]*SearchResult_Snippet, 0, len(result.Snippets)),
+		}
+		for _, snippet := range result.Snippets {
+			s := SearchResult_Snippet{
+				Fragments: make([]*SearchResult_Fragment, 0, len(snippet)),
+			}
+			for _, fragment := range snippet {
+				s.Fragments = append(s.Fragments, &SearchResult_Fragment{
+					Text:  fragment.Text,
+					Match: fragment.Match,
+				})
+			}
+			r.Snippets = append(r.Snippets, &s)
+		}
+		err := stream.Send(&SearchResponse{
+			Response: &SearchResponse_Result{Result: &r},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Complete completes the prefix of a title
+func (s *service) Complete(ctx context.Context, request *CompleteRequest) (*Completions, error) {
+	if request.N > MaxPerPage {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid n %d", request.N)
+	}
+	titles, err := s.encyclopedia.Complete(request.Prefix, int(request.N))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &Completions{Titles: titles}, nil
+}
+
+// Backlinks returns the articles that link to an article
+func (s *service) Backlinks(ctx context.Context, request *LookupRequest) (*Backlinks, error) {
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing article title")
+	}
+	titles, err := s.encyclopedia.Backlinks(title)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if titles == nil {
+		return nil, status.Error(codes.NotFound, "article not found: "+title)
+	}
+	return &Backlinks{Titles: titles}, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPC(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	GRPCServer(encyclopedia, server)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	ctx := context.Background()
+	connection, err := grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return listener.Dial()
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer connection.Close()
+	client := NewEncyclopediaClient(connection)
+	code := func(err error, expected codes.Code) {
+		if status.Code(err) != expected {
+			t.Fatal("invalid status", err, expected)
+		}
+	}
+
+	article, err := client.Lookup(ctx, &LookupRequest{Title: "USA"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if article.Title != "United States" || article.Redirect != "USA" || !article.Verify() {
+		t.Fatal("redirect should be followed", article.Title, article.Redirect)
+	}
+	_, err = client.Lookup(ctx, &LookupRequest{Title: "Atlantis"})
+	code(err, codes.NotFound)
+	_, err = client.Lookup(ctx, &LookupRequest{Title: "usa"})
+	code(err, codes.FailedPrecondition)
+	titles := []string{}
+	for _, detail := range status.Convert(err).Details() {
+		if failure, ok := detail.(*errdetails.PreconditionFailure); ok {
+			for _, violation := range failure.Violations {
+				titles = append(titles, violation.Subject)
+			}
+		}
+	}
+	if strings.Join(titles, "|") != "USA|Usa" {
+		t.Fatal("the titles should be in the details", titles)
+	}
+	_, err = client.Lookup(ctx, &LookupRequest{})
+	code(err, codes.InvalidArgument)
+
+	// search reads the summary and the results of the stream of a search
+	search := func(query *SearchQuery) (*SearchSummary, []*SearchResult, error) {
+		stream, err := client.Search(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		var summary *SearchSummary
+		results := make([]*SearchResult, 0, 1)
+		for {
+			response, err := stream.Recv()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, nil, err
+			}
+			if s := response.GetSummary(); s != nil {
+				summary = s
+			} else if summary == nil {
+				t.Fatal("the summary should be the first message")
+			} else {
+				results = append(results, response.GetResult())
+			}
+		}
+		return summary, results, nil
+	}
+	summary, results, err := search(&SearchQuery{Query: "york", Offset: 3, Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Total != 4 || summary.Scored != 4 || len(results) != 1 {
+		t.Fatal("invalid results", summary, len(results))
+	}
+	_, results, err = search(&SearchQuery{Query: "capital -country"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatal("invalid results", len(results))
+	}
+	result := results[0]
+	if result.Title != "Washington, D.C." || len(result.Snippets) != 1 ||
+		len(result.Snippets[0].Fragments) != 3 || !result.Snippets[0].Fragments[1].Match {
+		t.Fatal("invalid result", result)
+	}
+	// the suggestion isn't a header, so it can have a line break
+	summary, results, err = search(&SearchQuery{Query: "aple\ncity"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Suggestion != "apple\ncity" {
+		t.Fatal("invalid suggestion", summary)
+	}
+	_, _, err = search(&SearchQuery{Query: "c++ ("})
+	code(err, codes.InvalidArgument)
+
+	completions, err := client.Complete(ctx, &CompleteRequest{Prefix: "new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(completions.Titles, "|") != "New York City" {
+		t.Fatal("invalid completions", completions.Titles)
+	}
+	_, err = client.Complete(ctx, &CompleteRequest{Prefix: "new", N: MaxPerPage + 1})
+	code(err, codes.InvalidArgument)
+
+	backlinks, err := client.Backlinks(ctx, &LookupRequest{Title: "Washington, D.C."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(backlinks.Titles, "|") != "United States" {
+		t.Fatal("invalid backlinks", backlinks.Titles)
+	}
+	_, err = client.Backlinks(ctx, &LookupRequest{Title: "Atlantis"})
+	code(err, codes.NotFound)
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Field is a field of an infobox, the value is wikitext and the text is its
+// plain text
+type Field struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Text  string `json:"text"`
+	// expanded is the value with its templates expanded
+	expanded string
+}
+
+// Infobox is the infobox of an article, the type is the name of the template
+// without the Infobox prefix and the fields are in the order of the wikitext
+type Infobox struct {
+	Type   string  `json:"type"`
+	Fields []Field `json:"fields"`
+}
+
+// Get returns the field of an infobox with the name
+func (i *Infobox) Get(name string) (Field, bool) {
+	for _, field := range i.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// ParseInfobox parses the first {{Infobox ...}} template of wikitext into an
+// infobox and returns the wikitext without it. The infobox is nil if there
+// isn't one. The text of a field is the plain text of its value expanded by
+// expand, the value isn't expanded if expand is nil. The fields with empty
+// values are left out, and the value of a repeated field replaces the earlier
+// one.
+func ParseInfobox(text string, expand func(text string) (string, error)) (*Infobox, string, error) {
+	segments := preprocess(text)
+	for i, s := range segments {
+		if s.Kind != segmentTemplate {
+			continue
+		}
+		name := templateName(source(s.Parts[0]))
+		if j := strings.IndexByte(name, ':'); j >= 0 && strings.EqualFold(strings.TrimSpace(name[:j]), "template") {
+			name = templateName(name[j+1:])
+		}
+		if !strings.HasPrefix(name, "Infobox") {
+			continue
+		}
+		infobox, fields := &Infobox{Type: strings.TrimSpace(name[len("Infobox"):])}, make(map[string]int)
+		for _, part := range s.Parts[1:] {
+			name, value, named := argument(part)
+			if !named {
+				continue
+			}
+			field := Field{
+				Name:  strings.TrimSpace(source(name)),
+				Value: strings.TrimSpace(source(value)),
+			}
+			if field.Name == "" || field.Value == "" {
+				continue
+			}
+			expanded := field.Value
+			if expand != nil {
+				var err error
+				expanded, err = expand(field.Value)
+				if err != nil {
+					return nil, text, err
+				}
+				if expanded = strings.TrimSpace(expanded); expanded == "" {
+					continue
+				}
+			}
+			field.expanded = expanded
+			plain, err := WikiTextToText(expanded)
+			if err != nil {
+				return nil, text, err
+			}
+			field.Text = strings.TrimSpace(plain)
+			if j, has := fields[field.Name]; has {
+				infobox.Fields[j] = field
+				continue
+			}
+			fields[field.Name] = len(infobox.Fields)
+			infobox.Fields = append(infobox.Fields, field)
+		}
+		// the line break after the infobox is removed with it
+		rest := append(append([]segment{}, segments[:i]...), segments[i+1:]...)
+		if i < len(rest) && rest[i].Kind == segmentText {
+			rest[i].Text = strings.TrimPrefix(rest[i].Text, "\n")
+		}
+		return infobox, source(rest), nil
+	}
+	return nil, text, nil
+}
+
+// Infobox returns the infobox of the article, it is nil if there isn't one.
+// The templates of the values aren't expanded, see Encyclopedia.Infobox.
+func (a *Article) Infobox() (*Infobox, error) {
+	infobox, _, err := ParseInfobox(a.Text, nil)
+	return infobox, err
+}
+
+// Infobox returns the infobox of an article with the templates of the values
+// expanded for the text of the fields, it is nil if there isn't one
+func (e *Encyclopedia) Infobox(article *Article) (*Infobox, error) {
+	infobox, _, err := ParseInfobox(article.Text, e.Expand)
+	return infobox, err
+}
+
+// renderInfobox renders an infobox as a side table, the name field is the
+// caption and the labels are the names of the fields with spaces for
+// underscores. The values are rendered as they were expanded by ParseInfobox.
+func renderInfobox(title string, infobox *Infobox) (string, error) {
+	caption := title
+	if name, has := infobox.Get("name"); has {
+		caption = name.Text
+	}
+	text := fmt.Sprintf("<table class=\"infobox\">\n<caption>%s</caption>\n", template.HTMLEscapeString(caption))
+	for _, field := range infobox.Fields {
+		if field.Name == "name" {
+			continue
+		}
+		value := field.expanded
+		if value == "" {
+			value = field.Value
+		}
+		html, err := WikiTextToHTML(value)
+		if err != nil {
+			return "", err
+		}
+		label := templateName(strings.Replace(field.Name, "_", " ", -1))
+		text += fmt.Sprintf("<tr><th>%s</th><td>%s</td></tr>\n", template.HTMLEscapeString(label), strings.TrimSpace(html))
+	}
+	return text + "</table>\n", nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseInfobox(t *testing.T) {
+	text := "{{Short description|Painter}}\n{{infobox_person\n| name = Ada\n| birth_date = {{birth date|1815|12|10}}\n" +
+		"| birth_place = [[London]], England\n| image =\n| known_for = ''Notes'' | name = Ada Lovelace\n" +
+		"| spouse = {{#if:||}}\n}}\n'''Ada''' was a mathematician."
+	expand := func(text string) (string, error) {
+		return Expand(text, func(name string) (string, bool, error) {
+			if name == "Birth date" {
+				return "{{{1}}}-{{{2}}}-{{{3}}}", true, nil
+			}
+			return "", false, nil
+		})
+	}
+	infobox, rest, err := ParseInfobox(text, expand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if infobox == nil || infobox.Type != "person" || len(infobox.Fields) != 4 {
+		t.Fatal("invalid infobox", infobox)
+	}
+	names := []string{"name", "birth_date", "birth_place", "known_for"}
+	for i, field := range infobox.Fields {
+		if field.Name != names[i] {
+			t.Fatal("invalid field", i, field)
+		}
+	}
+	if field, has := infobox.Get("name"); !has || field.Value != "Ada Lovelace" {
+		t.Fatal("the repeated field should be replaced", field)
+	}
+	if field, _ := infobox.Get("birth_date"); field.Value != "{{birth date|1815|12|10}}" || field.Text != "1815-12-10" {
+		t.Fatal("the template of the value should be expanded", field)
+	}
+	if field, _ := infobox.Get("birth_place"); field.Text != "London, England" {
+		t.Fatal("invalid text", field)
+	}
+	if field, _ := infobox.Get("known_for"); field.Text != "Notes" {
+		t.Fatal("invalid text", field)
+	}
+	if _, has := infobox.Get("image"); has {
+		t.Fatal("the empty field should be left out")
+	}
+	if _, has := infobox.Get("spouse"); has {
+		t.Fatal("the field that expands to nothing should be left out")
+	}
+	if target := "{{Short description|Painter}}\n'''Ada''' was a mathematician."; rest != target {
+		t.Fatalf("not equal %q", rest)
+	}
+	// the values are rendered as they were expanded
+	table, err := renderInfobox("Ada", infobox)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(table, "<tr><th>Birth date</th><td>1815-12-10</td></tr>") {
+		t.Fatal("the expanded value should be rendered", table)
+	}
+
+	infobox, rest, err = ParseInfobox("no {{navbox|title=x}} infobox", expand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if infobox != nil || rest != "no {{navbox|title=x}} infobox" {
+		t.Fatal("there should be no infobox", infobox, rest)
+	}
+	article := Article{Text: "{{Template:Infobox|name=x|born={{birth date|1815|12|10}}}}"}
+	if infobox, err := article.Infobox(); err != nil || infobox == nil || infobox.Type != "" ||
+		infobox.Fields[1].Text != "{{birth date|1815|12|10}}" {
+		t.Fatal("the values shouldn't be expanded", infobox, err)
+	}
+}
@@ -7,9 +7,10 @@ package wikipedia
 // Node is an entry in the LRU cache
 type Node struct {
 	F, B  *Node
-	Index []uint32
+	Index *Index
 	Key   string
 	Seen  bool
+	Dirty bool
 }
 
 // LRU is a least recently used cache
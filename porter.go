@@ -0,0 +1,218 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+// PorterStemmer is the Porter stemming algorithm for english words
+type PorterStemmer struct{}
+
+// porter is the state of the stemming of a word, k is the end of the word and
+// j is the end of the stem before a matched suffix
+type porter struct {
+	b    []byte
+	k, j int
+}
+
+// cons returns true if the letter at i is a consonant
+func (p *porter) cons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.cons(i-1)
+	}
+	return true
+}
+
+// m measures the number of consonant vowel sequences in the stem
+func (p *porter) m() int {
+	n, i := 0, 0
+	for ; i <= p.j && p.cons(i); i++ {
+	}
+	for i <= p.j {
+		for ; i <= p.j && !p.cons(i); i++ {
+		}
+		if i > p.j {
+			break
+		}
+		n++
+		for ; i <= p.j && p.cons(i); i++ {
+		}
+	}
+	return n
+}
+
+// vowelinstem returns true if the stem has a vowel
+func (p *porter) vowelinstem() bool {
+	for i := 0; i <= p.j; i++ {
+		if !p.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doublec returns true if the word ends in a double consonant at i
+func (p *porter) doublec(i int) bool {
+	return i >= 1 && p.b[i] == p.b[i-1] && p.cons(i)
+}
+
+// cvc returns true if the letters ending at i are consonant vowel consonant
+// and the last consonant isn't w, x or y
+func (p *porter) cvc(i int) bool {
+	if i < 2 || !p.cons(i) || p.cons(i-1) || !p.cons(i-2) {
+		return false
+	}
+	switch p.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends returns true if the word ends with the suffix and sets the end of the stem
+func (p *porter) ends(suffix string) bool {
+	l := len(suffix)
+	if l > p.k+1 || string(p.b[p.k-l+1:p.k+1]) != suffix {
+		return false
+	}
+	p.j = p.k - l
+	return true
+}
+
+// setto replaces the suffix after the stem
+func (p *porter) setto(suffix string) {
+	p.b = append(p.b[:p.j+1], suffix...)
+	p.k = p.j + len(suffix)
+}
+
+// replace replaces the first suffix of the pairs of suffixes and replacements
+// that the word ends with if the measure of the stem is greater than min
+func (p *porter) replace(min int, pairs ...string) {
+	for i := 0; i < len(pairs); i += 2 {
+		if p.ends(pairs[i]) {
+			if p.m() > min {
+				p.setto(pairs[i+1])
+			}
+			return
+		}
+	}
+}
+
+// step1ab removes plurals and -ed or -ing
+func (p *porter) step1ab() {
+	if p.b[p.k] == 's' {
+		if p.ends("sses") {
+			p.k -= 2
+		} else if p.ends("ies") {
+			p.setto("i")
+		} else if p.b[p.k-1] != 's' {
+			p.k--
+		}
+	}
+	if p.ends("eed") {
+		if p.m() > 0 {
+			p.k--
+		}
+	} else if (p.ends("ed") || p.ends("ing")) && p.vowelinstem() {
+		p.k = p.j
+		if p.ends("at") {
+			p.setto("ate")
+		} else if p.ends("bl") {
+			p.setto("ble")
+		} else if p.ends("iz") {
+			p.setto("ize")
+		} else if p.doublec(p.k) {
+			p.k--
+			switch p.b[p.k] {
+			case 'l', 's', 'z':
+				p.k++
+			}
+		} else if p.m() == 1 && p.cvc(p.k) {
+			p.setto("e")
+		}
+	}
+}
+
+// step1c turns a terminal y into i when there is another vowel in the stem
+func (p *porter) step1c() {
+	if p.ends("y") && p.vowelinstem() {
+		p.b[p.k] = 'i'
+	}
+}
+
+// step2 maps double suffixes to single ones
+func (p *porter) step2() {
+	p.replace(0,
+		"ational", "ate", "tional", "tion", "enci", "ence", "anci", "ance",
+		"izer", "ize", "bli", "ble", "alli", "al", "entli", "ent", "eli", "e",
+		"ousli", "ous", "ization", "ize", "ation", "ate", "ator", "ate",
+		"alism", "al", "iveness", "ive", "fulness", "ful", "ousness", "ous",
+		"aliti", "al", "iviti", "ive", "biliti", "ble", "logi", "log")
+}
+
+// step3 deals with -ic-, -full, -ness etc.
+func (p *porter) step3() {
+	p.replace(0,
+		"icate", "ic", "ative", "", "alize", "al", "iciti", "ic", "ical", "ic",
+		"ful", "", "ness", "")
+}
+
+// step4 removes -ant, -ence etc. in a context of <c>vcvc<v>
+func (p *porter) step4() {
+	for _, suffix := range []string{"al", "ance", "ence", "er", "ic", "able",
+		"ible", "ant", "ement", "ment", "ent", "ion", "ou", "ism", "ate", "iti",
+		"ous", "ive", "ize"} {
+		if !p.ends(suffix) {
+			continue
+		}
+		if suffix == "ion" && (p.j < 0 || (p.b[p.j] != 's' && p.b[p.j] != 't')) {
+			continue
+		}
+		if p.m() > 1 {
+			p.k = p.j
+		}
+		return
+	}
+}
+
+// step5 removes a final -e and changes -ll to -l if the measure is greater than 1
+func (p *porter) step5() {
+	p.j = p.k
+	if p.b[p.k] == 'e' {
+		a := p.m()
+		if a > 1 || (a == 1 && !p.cvc(p.k-1)) {
+			p.k--
+		}
+	}
+	if p.b[p.k] == 'l' && p.doublec(p.k) && p.m() > 1 {
+		p.k--
+	}
+}
+
+// Stem stems a lower case word, words with letters outside of a-z are
+// returned unchanged
+func (PorterStemmer) Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+	p := porter{
+		b: []byte(word),
+		k: len(word) - 1,
+	}
+	p.step1ab()
+	if p.k > 0 {
+		p.step1c()
+		p.step2()
+		p.step3()
+		p.step4()
+		p.step5()
+	}
+	return string(p.b[:p.k+1])
+}
@@ -0,0 +1,476 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pointlander/wikipedia/query"
+
+	"github.com/boltdb/bolt"
+)
+
+var (
+	// MaxRegexLength is the maximum length of the regular expression of a term
+	MaxRegexLength = 256
+	// MaxRegexTerms is the maximum number of terms of the index the regular
+	// expression of a term can match
+	MaxRegexTerms = 1024
+	// MaxRegexScan is the maximum number of terms of the index that are read
+	// to expand the regular expression of a term, a regular expression
+	// without a literal prefix reads the terms from the start of the index
+	MaxRegexScan = 1 << 16
+)
+
+// QueryError is an error in the text of a query, such as a syntax error or an
+// invalid regular expression
+type QueryError struct {
+	// Query is the text of the query or of the term in error
+	Query string
+	Err   error
+}
+
+// Error returns the message of the error
+func (q *QueryError) Error() string {
+	return fmt.Sprintf("invalid query %q: %v", q.Query, q.Err)
+}
+
+// literal turns the regular expression terms of a syntax tree into words
+func literal(node *query.Node) {
+	node.Regex = false
+	for _, child := range node.Children {
+		literal(child)
+	}
+}
+
+// Clause is a clause of a query that is matched against the positions in the
+// index, it is either a phrase of terms or two clauses near each other
+type Clause struct {
+	// Terms are the terms of the phrase with their positions relative to the
+	// first term
+	Terms []Token
+	// Regex matches the terms of the index that can be at the position of the
+	// clause
+	Regex *regexp.Regexp
+	// Field is the field of the terms matched by Regex
+	Field string
+	// Near is the maximum distance between the Left and Right clauses
+	Near        uint32
+	Left, Right *Clause
+}
+
+// NewClause creates the clause of a term or near node of a query, the clause
+// is nil if the text of the node has no terms. A word is a phrase of the
+// terms it is analyzed into, and the terms of a field are prefixed with the
+// name of the field. The regular expression of a term matches whole terms
+// of the index, which are case folded and stemmed by the analyzer.
+func NewClause(analyzer *Analyzer, node *query.Node) (*Clause, error) {
+	if node.Operator == query.OperatorNear {
+		left, err := NewClause(analyzer, node.Children[0])
+		if err != nil {
+			return nil, err
+		}
+		right, err := NewClause(analyzer, node.Children[1])
+		if err != nil {
+			return nil, err
+		}
+		if left == nil {
+			return right, nil
+		} else if right == nil {
+			return left, nil
+		}
+		return &Clause{
+			Near:  uint32(node.Distance),
+			Left:  left,
+			Right: right,
+		}, nil
+	}
+	if node.Regex {
+		if len(node.Text) > MaxRegexLength {
+			return nil, &QueryError{
+				Query: node.String(),
+				Err:   fmt.Errorf("regular expression is longer than %d characters", MaxRegexLength),
+			}
+		}
+		// the expression is compiled on its own first so that it can't
+		// escape the anchors
+		_, err := regexp.Compile(node.Text)
+		if err != nil {
+			return nil, &QueryError{Query: node.String(), Err: err}
+		}
+		prefix := ""
+		if node.Field != "" {
+			prefix = regexp.QuoteMeta(node.Field + ":")
+		}
+		regex, err := regexp.Compile("^" + prefix + "(?:" + node.Text + ")$")
+		if err != nil {
+			return nil, &QueryError{Query: node.String(), Err: err}
+		}
+		return &Clause{Regex: regex, Field: node.Field}, nil
+	}
+	tokens := analyzer.Analyze(node.Text)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	first := tokens[0].Position
+	for i := range tokens {
+		tokens[i].Position -= first
+		if node.Field != "" {
+			tokens[i].Term = node.Field + ":" + tokens[i].Term
+		}
+	}
+	return &Clause{Terms: tokens}, nil
+}
+
+// String returns the query text of the clause
+func (c *Clause) String() string {
+	if c.Left != nil {
+		return c.Left.String() + " NEAR/" + strconv.Itoa(int(c.Near)) + " " + c.Right.String()
+	} else if c.Regex != nil {
+		return "/" + c.Regex.String() + "/"
+	}
+	terms := make([]string, len(c.Terms))
+	for i, term := range c.Terms {
+		terms[i] = term.Term
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return `"` + strings.Join(terms, " ") + `"`
+}
+
+// Span is the first and last position of a match of a clause in an article
+type Span struct {
+	Start, End uint32
+}
+
+// termCache caches the positions of terms in articles read from the index
+// and the terms matched by regular expressions
+type termCache struct {
+	idx     *bolt.Bucket
+	terms   map[string]map[uint32][]uint32
+	regexes map[string][]string
+}
+
+// get gets the positions of the term in the articles
+func (p *termCache) get(term string) (map[uint32][]uint32, error) {
+	if positions, has := p.terms[term]; has {
+		return positions, nil
+	}
+	index, err := getIndex(p.idx, term)
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[uint32][]uint32)
+	for _, posting := range DecodeIndex(index) {
+		positions[posting.Index] = posting.Positions
+	}
+	p.terms[term] = positions
+	return positions, nil
+}
+
+// expand returns the terms of the field that the regular expression matches,
+// the terms are read starting from the literal prefix of the expression or
+// of the field and at most MaxRegexScan terms are read
+func (p *termCache) expand(regex *regexp.Regexp, field string) ([]string, error) {
+	if terms, has := p.regexes[regex.String()]; has {
+		return terms, nil
+	}
+	literal, _ := regex.LiteralPrefix()
+	prefix := []byte(literal)
+	if field != "" && !bytes.HasPrefix(prefix, []byte(field+":")) {
+		prefix = []byte(field + ":")
+	}
+	terms, cursor, scanned := make([]string, 0, 8), p.idx.Cursor(), 0
+	for key, _ := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = cursor.Next() {
+		if scanned == MaxRegexScan {
+			return nil, &QueryError{
+				Query: "/" + regex.String() + "/",
+				Err:   fmt.Errorf("regular expression reads more than %d terms of the index, it needs a longer literal prefix", MaxRegexScan),
+			}
+		}
+		scanned++
+		name := ""
+		if i := bytes.IndexByte(key, ':'); i >= 0 {
+			name = string(key[:i])
+		}
+		if name != field {
+			// the terms of the other fields
+			continue
+		}
+		if !regex.Match(key) {
+			continue
+		}
+		if len(terms) == MaxRegexTerms {
+			return nil, &QueryError{
+				Query: "/" + regex.String() + "/",
+				Err:   fmt.Errorf("regular expression matches more than %d terms", MaxRegexTerms),
+			}
+		}
+		terms = append(terms, string(key))
+	}
+	p.regexes[regex.String()] = terms
+	return terms, nil
+}
+
+// match returns the spans of the matches of the clause in the articles
+func (c *Clause) match(p *termCache) (map[uint32][]Span, error) {
+	matches := make(map[uint32][]Span)
+	if c.Left != nil {
+		left, err := c.Left.match(p)
+		if err != nil {
+			return nil, err
+		}
+		right, err := c.Right.match(p)
+		if err != nil {
+			return nil, err
+		}
+		for index, a := range left {
+			b, has := right[index]
+			if !has {
+				continue
+			}
+			for _, x := range a {
+				for _, y := range b {
+					var distance uint32
+					if x.End < y.Start {
+						distance = y.Start - x.End
+					} else if y.End < x.Start {
+						distance = x.Start - y.End
+					}
+					if distance > c.Near {
+						continue
+					}
+					span := x
+					if y.Start < span.Start {
+						span.Start = y.Start
+					}
+					if y.End > span.End {
+						span.End = y.End
+					}
+					matches[index] = append(matches[index], span)
+				}
+			}
+		}
+		return matches, nil
+	} else if c.Regex != nil {
+		terms, err := p.expand(c.Regex, c.Field)
+		if err != nil {
+			return nil, err
+		}
+		for _, term := range terms {
+			articles, err := p.get(term)
+			if err != nil {
+				return nil, err
+			}
+			for index, positions := range articles {
+				for _, position := range positions {
+					matches[index] = append(matches[index], Span{
+						Start: position,
+						End:   position,
+					})
+				}
+			}
+		}
+		for _, spans := range matches {
+			sort.Slice(spans, func(i, j int) bool {
+				return spans[i].Start < spans[j].Start
+			})
+		}
+		return matches, nil
+	}
+
+	first, err := p.get(c.Terms[0].Term)
+	if err != nil {
+		return nil, err
+	}
+	rest := make([]map[uint32][]uint32, len(c.Terms)-1)
+	for i, term := range c.Terms[1:] {
+		rest[i], err = p.get(term.Term)
+		if err != nil {
+			return nil, err
+		}
+	}
+	last := c.Terms[len(c.Terms)-1].Position
+	for index, positions := range first {
+		for _, position := range positions {
+			found := true
+			for i, term := range c.Terms[1:] {
+				if !contains(rest[i][index], position+term.Position) {
+					found = false
+					break
+				}
+			}
+			if found {
+				matches[index] = append(matches[index], Span{
+					Start: position,
+					End:   position + last,
+				})
+			}
+		}
+	}
+	return matches, nil
+}
+
+// contains returns true if the ascending positions contain the position
+func contains(positions []uint32, position uint32) bool {
+	i := sort.Search(len(positions), func(i int) bool {
+		return positions[i] >= position
+	})
+	return i < len(positions) && positions[i] == position
+}
+
+// set is a set of article indexes
+type set map[uint32]bool
+
+// evaluator evaluates the syntax tree of a query against the index
+type evaluator struct {
+	analyzer *Analyzer
+	cache    termCache
+	// matches are the matches of the clauses that aren't negated, they
+	// score the articles
+	matches []map[uint32][]Span
+	// terms are the terms of the text of the clauses that aren't negated,
+	// they are marked in the snippets
+	terms map[string]bool
+}
+
+// mark adds the terms of the text of the clause to the marked terms
+func (e *evaluator) mark(clause *Clause) error {
+	if clause.Left != nil {
+		if err := e.mark(clause.Left); err != nil {
+			return err
+		}
+		return e.mark(clause.Right)
+	}
+	terms := make([]string, 0, len(clause.Terms))
+	if clause.Regex != nil {
+		var err error
+		terms, err = e.cache.expand(clause.Regex, clause.Field)
+		if err != nil {
+			return err
+		}
+	}
+	for _, token := range clause.Terms {
+		terms = append(terms, token.Term)
+	}
+	for _, term := range terms {
+		if strings.IndexByte(term, ':') < 0 {
+			e.terms[term] = true
+		}
+	}
+	return nil
+}
+
+// evaluate returns the articles that match the node, the set is nil if the
+// node doesn't constrain the articles such as a word that is a stop word
+func (e *evaluator) evaluate(node *query.Node, negated bool) (set, error) {
+	switch node.Operator {
+	case query.OperatorTerm, query.OperatorNear:
+		clause, err := NewClause(e.analyzer, node)
+		if err != nil {
+			return nil, err
+		} else if clause == nil {
+			return nil, nil
+		}
+		spans, err := clause.match(&e.cache)
+		if err != nil {
+			return nil, err
+		}
+		if !negated {
+			e.matches = append(e.matches, spans)
+			if err := e.mark(clause); err != nil {
+				return nil, err
+			}
+		}
+		matches := make(set, len(spans))
+		for index := range spans {
+			matches[index] = true
+		}
+		return matches, nil
+	case query.OperatorRequired:
+		return e.evaluate(node.Children[0], negated)
+	case query.OperatorNot:
+		// the articles not matching a clause can't be enumerated, so a not
+		// clause only excludes articles from its group
+		return set{}, nil
+	case query.OperatorOr:
+		var union set
+		for _, child := range node.Children {
+			matches, err := e.evaluate(child, negated)
+			if err != nil {
+				return nil, err
+			} else if matches == nil {
+				continue
+			}
+			if union == nil {
+				union = make(set)
+			}
+			for index := range matches {
+				union[index] = true
+			}
+		}
+		return union, nil
+	}
+
+	// groups and ands: the should clauses are united unless there are
+	// required clauses, and the not clauses are subtracted
+	var should, must, not set
+	for _, child := range node.Children {
+		target, operator := child, child.Operator
+		if operator == query.OperatorNot || operator == query.OperatorRequired {
+			target = child.Children[0]
+		} else if node.Operator == query.OperatorAnd {
+			operator = query.OperatorRequired
+		}
+		matches, err := e.evaluate(target, negated != (operator == query.OperatorNot))
+		if err != nil {
+			return nil, err
+		} else if matches == nil {
+			continue
+		}
+		switch operator {
+		case query.OperatorNot:
+			if not == nil {
+				not = make(set)
+			}
+			for index := range matches {
+				not[index] = true
+			}
+		case query.OperatorRequired:
+			if must == nil {
+				must = matches
+				continue
+			}
+			for index := range must {
+				if !matches[index] {
+					delete(must, index)
+				}
+			}
+		default:
+			if should == nil {
+				should = make(set)
+			}
+			for index := range matches {
+				should[index] = true
+			}
+		}
+	}
+	result := should
+	if must != nil {
+		result = must
+	} else if result == nil && not != nil {
+		result = set{}
+	}
+	for index := range not {
+		delete(result, index)
+	}
+	return result, nil
+}
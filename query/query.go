@@ -0,0 +1,183 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package query parses search queries into syntax trees
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Operator is the operator of a node of a query
+type Operator int
+
+const (
+	// OperatorTerm is a word or a quoted phrase
+	OperatorTerm Operator = iota
+	// OperatorGroup is a sequence of clauses, the articles match if they match
+	// any of the clauses, all of the required clauses and none of the not clauses
+	OperatorGroup
+	// OperatorOr is the union of the clauses
+	OperatorOr
+	// OperatorAnd is the intersection of the clauses less the not clauses
+	OperatorAnd
+	// OperatorNot excludes the clause
+	OperatorNot
+	// OperatorRequired requires the clause
+	OperatorRequired
+	// OperatorNear is two clauses at most a distance apart
+	OperatorNear
+)
+
+// Node is a node of the syntax tree of a query
+type Node struct {
+	Operator Operator
+	// Field is the field of a term: title, category or empty for the text
+	Field string
+	// Text is the text of a term
+	Text string
+	// Phrase is true if the term is quoted
+	Phrase bool
+	// Regex is true if the text of the term is a regular expression
+	Regex bool
+	// Distance is the maximum distance between the clauses of a near node
+	Distance int
+	Children []*Node
+}
+
+// Parse parses a query into a syntax tree, the tree is nil if the query is empty
+func Parse(query string) (*Node, error) {
+	parser := &Query{Buffer: query}
+	parser.Init()
+	if err := parser.Parse(); err != nil {
+		if e, ok := err.(*parseError); ok {
+			return nil, fmt.Errorf("syntax error at character %d", e.max.end)
+		}
+		return nil, err
+	}
+	text := func(node *node32) string {
+		return string(parser.buffer[node.begin:node.end])
+	}
+
+	var build func(node *node32) *Node
+	// children builds the children of a node with the rule
+	children := func(node *node32, operator Operator) *Node {
+		n := &Node{Operator: operator}
+		for node = node.up; node != nil; node = node.next {
+			if child := build(node); child != nil {
+				n.Children = append(n.Children, child)
+			}
+		}
+		if len(n.Children) == 1 && operator != OperatorNot && operator != OperatorRequired {
+			return n.Children[0]
+		}
+		return n
+	}
+	build = func(node *node32) *Node {
+		switch node.pegRule {
+		case rulegroup:
+			return children(node, OperatorGroup)
+		case ruleor:
+			return children(node, OperatorOr)
+		case ruleand:
+			return children(node, OperatorAnd)
+		case ruleunary, ruleprimary:
+			return children(node, OperatorGroup)
+		case rulerequired:
+			return children(node, OperatorRequired)
+		case ruleexcluded, rulenot:
+			return children(node, OperatorNot)
+		case rulenear:
+			n := children(node, OperatorNear)
+			if n.Operator != OperatorNear {
+				return n
+			}
+			// NEAR is left associative
+			near, distances := n.Children[0], make([]int, 0, 8)
+			for child := node.up; child != nil; child = child.next {
+				if child.pegRule == ruledistance {
+					distance, err := strconv.Atoi(text(child))
+					if err != nil {
+						distance = int(^uint(0) >> 1)
+					}
+					distances = append(distances, distance)
+				}
+			}
+			for i, child := range n.Children[1:] {
+				near = &Node{
+					Operator: OperatorNear,
+					Distance: distances[i],
+					Children: []*Node{near, child},
+				}
+			}
+			return near
+		case ruleterm:
+			n := &Node{Operator: OperatorTerm}
+			for child := node.up; child != nil; child = child.next {
+				switch child.pegRule {
+				case rulefield:
+					n.Field = text(child)
+				case rulephrase:
+					n.Phrase, n.Text = true, text(child.up)
+				case ruleregex:
+					n.Regex, n.Text = true, text(child.up)
+				case ruleword:
+					n.Text = text(child)
+				}
+			}
+			return n
+		}
+		return nil
+	}
+	for node := parser.AST(); node != nil; node = node.next {
+		if node.pegRule != rulequery {
+			continue
+		}
+		for child := node.up; child != nil; child = child.next {
+			if child.pegRule == rulegroup {
+				return build(child), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// String returns the query text of the node
+func (n *Node) String() string {
+	join := func(separator string) string {
+		children := make([]string, len(n.Children))
+		for i, child := range n.Children {
+			children[i] = child.String()
+		}
+		return strings.Join(children, separator)
+	}
+	switch n.Operator {
+	case OperatorTerm:
+		text := n.Text
+		if n.Phrase {
+			text = `"` + text + `"`
+		} else if n.Regex {
+			text = "/" + text + "/"
+		}
+		if n.Field != "" {
+			text = n.Field + ":" + text
+		}
+		return text
+	case OperatorGroup:
+		return "(" + join(" ") + ")"
+	case OperatorOr:
+		return "(" + join(" OR ") + ")"
+	case OperatorAnd:
+		return "(" + join(" AND ") + ")"
+	case OperatorNot:
+		return "NOT " + join("")
+	case OperatorRequired:
+		return "+" + join("")
+	case OperatorNear:
+		return "(" + join(" NEAR/"+strconv.Itoa(n.Distance)+" ") + ")"
+	}
+	return ""
+}
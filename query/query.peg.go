@@ -0,0 +1,1335 @@
+package query
+
+// Code generated by peg query.peg DO NOT EDIT.
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const endSymbol rune = 1114112
+
+/* The rule types inferred from the grammar are below. */
+type pegRule uint8
+
+const (
+	ruleUnknown pegRule = iota
+	rulequery
+	rulegroup
+	ruleor
+	ruleand
+	ruleunary
+	rulerequired
+	ruleexcluded
+	rulenot
+	rulenear
+	ruledistance
+	ruleprimary
+	ruleterm
+	rulefield
+	rulephrase
+	ruletext
+	ruleregex
+	rulepattern
+	ruleword
+	rulekeyword
+	rulesp
+	rulesp1
+	rulespace
+)
+
+var rul3s = [...]string{
+	"Unknown",
+	"query",
+	"group",
+	"or",
+	"and",
+	"unary",
+	"required",
+	"excluded",
+	"not",
+	"near",
+	"distance",
+	"primary",
+	"term",
+	"field",
+	"phrase",
+	"text",
+	"regex",
+	"pattern",
+	"word",
+	"keyword",
+	"sp",
+	"sp1",
+	"space",
+}
+
+type token32 struct {
+	pegRule
+	begin, end uint32
+}
+
+func (t *token32) String() string {
+	return fmt.Sprintf("\x1B[34m%v\x1B[m %v %v", rul3s[t.pegRule], t.begin, t.end)
+}
+
+type node32 struct {
+	token32
+	up, next *node32
+}
+
+func (node *node32) print(w io.Writer, pretty bool, buffer string) {
+	var print func(node *node32, depth int)
+	print = func(node *node32, depth int) {
+		for node != nil {
+			for c := 0; c < depth; c++ {
+				fmt.Fprintf(w, " ")
+			}
+			rule := rul3s[node.pegRule]
+			quote := strconv.Quote(string(([]rune(buffer)[node.begin:node.end])))
+			if !pretty {
+				fmt.Fprintf(w, "%v %v\n", rule, quote)
+			} else {
+				fmt.Fprintf(w, "\x1B[36m%v\x1B[m %v\n", rule, quote)
+			}
+			if node.up != nil {
+				print(node.up, depth+1)
+			}
+			node = node.next
+		}
+	}
+	print(node, 0)
+}
+
+func (node *node32) Print(w io.Writer, buffer string) {
+	node.print(w, false, buffer)
+}
+
+func (node *node32) PrettyPrint(w io.Writer, buffer string) {
+	node.print(w, true, buffer)
+}
+
+type tokens32 struct {
+	tree []token32
+}
+
+func (t *tokens32) Trim(length uint32) {
+	t.tree = t.tree[:length]
+}
+
+func (t *tokens32) Print() {
+	for _, token := range t.tree {
+		fmt.Println(token.String())
+	}
+}
+
+func (t *tokens32) AST() *node32 {
+	type element struct {
+		node *node32
+		down *element
+	}
+	tokens := t.Tokens()
+	var stack *element
+	for _, token := range tokens {
+		if token.begin == token.end {
+			continue
+		}
+		node := &node32{token32: token}
+		for stack != nil && stack.node.begin >= token.begin && stack.node.end <= token.end {
+			stack.node.next = node.up
+			node.up = stack.node
+			stack = stack.down
+		}
+		stack = &element{node: node, down: stack}
+	}
+	if stack != nil {
+		return stack.node
+	}
+	return nil
+}
+
+func (t *tokens32) PrintSyntaxTree(buffer string) {
+	t.AST().Print(os.Stdout, buffer)
+}
+
+func (t *tokens32) WriteSyntaxTree(w io.Writer, buffer string) {
+	t.AST().Print(w, buffer)
+}
+
+func (t *tokens32) PrettyPrintSyntaxTree(buffer string) {
+	t.AST().PrettyPrint(os.Stdout, buffer)
+}
+
+func (t *tokens32) Add(rule pegRule, begin, end, index uint32) {
+	tree, i := t.tree, int(index)
+	if i >= len(tree) {
+		t.tree = append(tree, token32{pegRule: rule, begin: begin, end: end})
+		return
+	}
+	tree[i] = token32{pegRule: rule, begin: begin, end: end}
+}
+
+func (t *tokens32) Tokens() []token32 {
+	return t.tree
+}
+
+type Query struct {
+	Buffer string
+	buffer []rune
+	rules  [23]func() bool
+	parse  func(rule ...int) error
+	reset  func()
+	Pretty bool
+	tokens32
+}
+
+func (p *Query) Parse(rule ...int) error {
+	return p.parse(rule...)
+}
+
+func (p *Query) Reset() {
+	p.reset()
+}
+
+type textPosition struct {
+	line, symbol int
+}
+
+type textPositionMap map[int]textPosition
+
+func translatePositions(buffer []rune, positions []int) textPositionMap {
+	length, translations, j, line, symbol := len(positions), make(textPositionMap, len(positions)), 0, 1, 0
+	sort.Ints(positions)
+
+search:
+	for i, c := range buffer {
+		if c == '\n' {
+			line, symbol = line+1, 0
+		} else {
+			symbol++
+		}
+		if i == positions[j] {
+			translations[positions[j]] = textPosition{line, symbol}
+			for j++; j < length; j++ {
+				if i != positions[j] {
+					continue search
+				}
+			}
+			break search
+		}
+	}
+
+	return translations
+}
+
+type parseError struct {
+	p   *Query
+	max token32
+}
+
+func (e *parseError) Error() string {
+	tokens, err := []token32{e.max}, "\n"
+	positions, p := make([]int, 2*len(tokens)), 0
+	for _, token := range tokens {
+		positions[p], p = int(token.begin), p+1
+		positions[p], p = int(token.end), p+1
+	}
+	translations := translatePositions(e.p.buffer, positions)
+	format := "parse error near %v (line %v symbol %v - line %v symbol %v):\n%v\n"
+	if e.p.Pretty {
+		format = "parse error near \x1B[34m%v\x1B[m (line %v symbol %v - line %v symbol %v):\n%v\n"
+	}
+	for _, token := range tokens {
+		begin, end := int(token.begin), int(token.end)
+		err += fmt.Sprintf(format,
+			rul3s[token.pegRule],
+			translations[begin].line, translations[begin].symbol,
+			translations[end].line, translations[end].symbol,
+			strconv.Quote(string(e.p.buffer[begin:end])))
+	}
+
+	return err
+}
+
+func (p *Query) PrintSyntaxTree() {
+	if p.Pretty {
+		p.tokens32.PrettyPrintSyntaxTree(p.Buffer)
+	} else {
+		p.tokens32.PrintSyntaxTree(p.Buffer)
+	}
+}
+
+func (p *Query) WriteSyntaxTree(w io.Writer) {
+	p.tokens32.WriteSyntaxTree(w, p.Buffer)
+}
+
+func (p *Query) SprintSyntaxTree() string {
+	var bldr strings.Builder
+	p.WriteSyntaxTree(&bldr)
+	return bldr.String()
+}
+
+func Pretty(pretty bool) func(*Query) error {
+	return func(p *Query) error {
+		p.Pretty = pretty
+		return nil
+	}
+}
+
+func Size(size int) func(*Query) error {
+	return func(p *Query) error {
+		p.tokens32 = tokens32{tree: make([]token32, 0, size)}
+		return nil
+	}
+}
+func (p *Query) Init(options ...func(*Query) error) error {
+	var (
+		max                  token32
+		position, tokenIndex uint32
+		buffer               []rune
+	)
+	for _, option := range options {
+		err := option(p)
+		if err != nil {
+			return err
+		}
+	}
+	p.reset = func() {
+		max = token32{}
+		position, tokenIndex = 0, 0
+
+		p.buffer = []rune(p.Buffer)
+		if len(p.buffer) == 0 || p.buffer[len(p.buffer)-1] != endSymbol {
+			p.buffer = append(p.buffer, endSymbol)
+		}
+		buffer = p.buffer
+	}
+	p.reset()
+
+	_rules := p.rules
+	tree := p.tokens32
+	p.parse = func(rule ...int) error {
+		r := 1
+		if len(rule) > 0 {
+			r = rule[0]
+		}
+		matches := p.rules[r]()
+		p.tokens32 = tree
+		if matches {
+			p.Trim(tokenIndex)
+			return nil
+		}
+		return &parseError{p, max}
+	}
+
+	add := func(rule pegRule, begin uint32) {
+		tree.Add(rule, begin, position, tokenIndex)
+		tokenIndex++
+		if begin != position && position > max.end {
+			max = token32{rule, begin, position}
+		}
+	}
+
+	matchDot := func() bool {
+		if buffer[position] != endSymbol {
+			position++
+			return true
+		}
+		return false
+	}
+
+	/*matchChar := func(c byte) bool {
+		if buffer[position] == c {
+			position++
+			return true
+		}
+		return false
+	}*/
+
+	/*matchRange := func(lower byte, upper byte) bool {
+		if c := buffer[position]; c >= lower && c <= upper {
+			position++
+			return true
+		}
+		return false
+	}*/
+
+	_rules = [...]func() bool{
+		nil,
+		/* 0 query <- <(sp group? sp !.)> */
+		func() bool {
+			position0, tokenIndex0 := position, tokenIndex
+			{
+				position1 := position
+				if !_rules[rulesp]() {
+					goto l0
+				}
+				{
+					position2, tokenIndex2 := position, tokenIndex
+					if !_rules[rulegroup]() {
+						goto l2
+					}
+					goto l3
+				l2:
+					position, tokenIndex = position2, tokenIndex2
+				}
+			l3:
+				if !_rules[rulesp]() {
+					goto l0
+				}
+				{
+					position4, tokenIndex4 := position, tokenIndex
+					if !matchDot() {
+						goto l4
+					}
+					goto l0
+				l4:
+					position, tokenIndex = position4, tokenIndex4
+				}
+				add(rulequery, position1)
+			}
+			return true
+		l0:
+			position, tokenIndex = position0, tokenIndex0
+			return false
+		},
+		/* 1 group <- <(or (sp1 or)*)> */
+		func() bool {
+			position5, tokenIndex5 := position, tokenIndex
+			{
+				position6 := position
+				if !_rules[ruleor]() {
+					goto l5
+				}
+			l7:
+				{
+					position8, tokenIndex8 := position, tokenIndex
+					if !_rules[rulesp1]() {
+						goto l8
+					}
+					if !_rules[ruleor]() {
+						goto l8
+					}
+					goto l7
+				l8:
+					position, tokenIndex = position8, tokenIndex8
+				}
+				add(rulegroup, position6)
+			}
+			return true
+		l5:
+			position, tokenIndex = position5, tokenIndex5
+			return false
+		},
+		/* 2 or <- <(and (sp1 ('O' 'R') sp1 and)*)> */
+		func() bool {
+			position9, tokenIndex9 := position, tokenIndex
+			{
+				position10 := position
+				if !_rules[ruleand]() {
+					goto l9
+				}
+			l11:
+				{
+					position12, tokenIndex12 := position, tokenIndex
+					if !_rules[rulesp1]() {
+						goto l12
+					}
+					if buffer[position] != rune('O') {
+						goto l12
+					}
+					position++
+					if buffer[position] != rune('R') {
+						goto l12
+					}
+					position++
+					if !_rules[rulesp1]() {
+						goto l12
+					}
+					if !_rules[ruleand]() {
+						goto l12
+					}
+					goto l11
+				l12:
+					position, tokenIndex = position12, tokenIndex12
+				}
+				add(ruleor, position10)
+			}
+			return true
+		l9:
+			position, tokenIndex = position9, tokenIndex9
+			return false
+		},
+		/* 3 and <- <(unary (sp1 ('A' 'N' 'D') sp1 unary)*)> */
+		func() bool {
+			position13, tokenIndex13 := position, tokenIndex
+			{
+				position14 := position
+				if !_rules[ruleunary]() {
+					goto l13
+				}
+			l15:
+				{
+					position16, tokenIndex16 := position, tokenIndex
+					if !_rules[rulesp1]() {
+						goto l16
+					}
+					if buffer[position] != rune('A') {
+						goto l16
+					}
+					position++
+					if buffer[position] != rune('N') {
+						goto l16
+					}
+					position++
+					if buffer[position] != rune('D') {
+						goto l16
+					}
+					position++
+					if !_rules[rulesp1]() {
+						goto l16
+					}
+					if !_rules[ruleunary]() {
+						goto l16
+					}
+					goto l15
+				l16:
+					position, tokenIndex = position16, tokenIndex16
+				}
+				add(ruleand, position14)
+			}
+			return true
+		l13:
+			position, tokenIndex = position13, tokenIndex13
+			return false
+		},
+		/* 4 unary <- <(required / excluded / not / near)> */
+		func() bool {
+			position17, tokenIndex17 := position, tokenIndex
+			{
+				position18 := position
+				{
+					position19, tokenIndex19 := position, tokenIndex
+					if !_rules[rulerequired]() {
+						goto l20
+					}
+					goto l19
+				l20:
+					position, tokenIndex = position19, tokenIndex19
+					if !_rules[ruleexcluded]() {
+						goto l21
+					}
+					goto l19
+				l21:
+					position, tokenIndex = position19, tokenIndex19
+					if !_rules[rulenot]() {
+						goto l22
+					}
+					goto l19
+				l22:
+					position, tokenIndex = position19, tokenIndex19
+					if !_rules[rulenear]() {
+						goto l17
+					}
+				}
+			l19:
+				add(ruleunary, position18)
+			}
+			return true
+		l17:
+			position, tokenIndex = position17, tokenIndex17
+			return false
+		},
+		/* 5 required <- <('+' unary)> */
+		func() bool {
+			position23, tokenIndex23 := position, tokenIndex
+			{
+				position24 := position
+				if buffer[position] != rune('+') {
+					goto l23
+				}
+				position++
+				if !_rules[ruleunary]() {
+					goto l23
+				}
+				add(rulerequired, position24)
+			}
+			return true
+		l23:
+			position, tokenIndex = position23, tokenIndex23
+			return false
+		},
+		/* 6 excluded <- <('-' unary)> */
+		func() bool {
+			position25, tokenIndex25 := position, tokenIndex
+			{
+				position26 := position
+				if buffer[position] != rune('-') {
+					goto l25
+				}
+				position++
+				if !_rules[ruleunary]() {
+					goto l25
+				}
+				add(ruleexcluded, position26)
+			}
+			return true
+		l25:
+			position, tokenIndex = position25, tokenIndex25
+			return false
+		},
+		/* 7 not <- <('N' 'O' 'T' sp1 unary)> */
+		func() bool {
+			position27, tokenIndex27 := position, tokenIndex
+			{
+				position28 := position
+				if buffer[position] != rune('N') {
+					goto l27
+				}
+				position++
+				if buffer[position] != rune('O') {
+					goto l27
+				}
+				position++
+				if buffer[position] != rune('T') {
+					goto l27
+				}
+				position++
+				if !_rules[rulesp1]() {
+					goto l27
+				}
+				if !_rules[ruleunary]() {
+					goto l27
+				}
+				add(rulenot, position28)
+			}
+			return true
+		l27:
+			position, tokenIndex = position27, tokenIndex27
+			return false
+		},
+		/* 8 near <- <(primary (sp1 ('N' 'E' 'A' 'R' '/') distance sp1 primary)*)> */
+		func() bool {
+			position29, tokenIndex29 := position, tokenIndex
+			{
+				position30 := position
+				if !_rules[ruleprimary]() {
+					goto l29
+				}
+			l31:
+				{
+					position32, tokenIndex32 := position, tokenIndex
+					if !_rules[rulesp1]() {
+						goto l32
+					}
+					if buffer[position] != rune('N') {
+						goto l32
+					}
+					position++
+					if buffer[position] != rune('E') {
+						goto l32
+					}
+					position++
+					if buffer[position] != rune('A') {
+						goto l32
+					}
+					position++
+					if buffer[position] != rune('R') {
+						goto l32
+					}
+					position++
+					if buffer[position] != rune('/') {
+						goto l32
+					}
+					position++
+					if !_rules[ruledistance]() {
+						goto l32
+					}
+					if !_rules[rulesp1]() {
+						goto l32
+					}
+					if !_rules[ruleprimary]() {
+						goto l32
+					}
+					goto l31
+				l32:
+					position, tokenIndex = position32, tokenIndex32
+				}
+				add(rulenear, position30)
+			}
+			return true
+		l29:
+			position, tokenIndex = position29, tokenIndex29
+			return false
+		},
+		/* 9 distance <- <[0-9]+> */
+		func() bool {
+			position33, tokenIndex33 := position, tokenIndex
+			{
+				position34 := position
+				if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l33
+				}
+				position++
+			l35:
+				{
+					position36, tokenIndex36 := position, tokenIndex
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l36
+					}
+					position++
+					goto l35
+				l36:
+					position, tokenIndex = position36, tokenIndex36
+				}
+				add(ruledistance, position34)
+			}
+			return true
+		l33:
+			position, tokenIndex = position33, tokenIndex33
+			return false
+		},
+		/* 10 primary <- <(('(' sp group sp ')') / term)> */
+		func() bool {
+			position37, tokenIndex37 := position, tokenIndex
+			{
+				position38 := position
+				{
+					position39, tokenIndex39 := position, tokenIndex
+					if buffer[position] != rune('(') {
+						goto l40
+					}
+					position++
+					if !_rules[rulesp]() {
+						goto l40
+					}
+					if !_rules[rulegroup]() {
+						goto l40
+					}
+					if !_rules[rulesp]() {
+						goto l40
+					}
+					if buffer[position] != rune(')') {
+						goto l40
+					}
+					position++
+					goto l39
+				l40:
+					position, tokenIndex = position39, tokenIndex39
+					if !_rules[ruleterm]() {
+						goto l37
+					}
+				}
+			l39:
+				add(ruleprimary, position38)
+			}
+			return true
+		l37:
+			position, tokenIndex = position37, tokenIndex37
+			return false
+		},
+		/* 11 term <- <((field ':')? (phrase / regex / word))> */
+		func() bool {
+			position41, tokenIndex41 := position, tokenIndex
+			{
+				position42 := position
+				{
+					position43, tokenIndex43 := position, tokenIndex
+					if !_rules[rulefield]() {
+						goto l43
+					}
+					if buffer[position] != rune(':') {
+						goto l43
+					}
+					position++
+					goto l44
+				l43:
+					position, tokenIndex = position43, tokenIndex43
+				}
+			l44:
+				{
+					position45, tokenIndex45 := position, tokenIndex
+					if !_rules[rulephrase]() {
+						goto l46
+					}
+					goto l45
+				l46:
+					position, tokenIndex = position45, tokenIndex45
+					if !_rules[ruleregex]() {
+						goto l47
+					}
+					goto l45
+				l47:
+					position, tokenIndex = position45, tokenIndex45
+					if !_rules[ruleword]() {
+						goto l41
+					}
+				}
+			l45:
+				add(ruleterm, position42)
+			}
+			return true
+		l41:
+			position, tokenIndex = position41, tokenIndex41
+			return false
+		},
+		/* 12 field <- <(('t' 'i' 't' 'l' 'e') / ('c' 'a' 't' 'e' 'g' 'o' 'r' 'y'))> */
+		func() bool {
+			position48, tokenIndex48 := position, tokenIndex
+			{
+				position49 := position
+				{
+					position50, tokenIndex50 := position, tokenIndex
+					if buffer[position] != rune('t') {
+						goto l51
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l51
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l51
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l51
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l51
+					}
+					position++
+					goto l50
+				l51:
+					position, tokenIndex = position50, tokenIndex50
+					if buffer[position] != rune('c') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('g') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('o') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('r') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('y') {
+						goto l48
+					}
+					position++
+				}
+			l50:
+				add(rulefield, position49)
+			}
+			return true
+		l48:
+			position, tokenIndex = position48, tokenIndex48
+			return false
+		},
+		/* 13 phrase <- <('"' text '"')> */
+		func() bool {
+			position52, tokenIndex52 := position, tokenIndex
+			{
+				position53 := position
+				if buffer[position] != rune('"') {
+					goto l52
+				}
+				position++
+				if !_rules[ruletext]() {
+					goto l52
+				}
+				if buffer[position] != rune('"') {
+					goto l52
+				}
+				position++
+				add(rulephrase, position53)
+			}
+			return true
+		l52:
+			position, tokenIndex = position52, tokenIndex52
+			return false
+		},
+		/* 14 text <- <(!'"' .)*> */
+		func() bool {
+			{
+				position55 := position
+			l56:
+				{
+					position57, tokenIndex57 := position, tokenIndex
+					{
+						position58, tokenIndex58 := position, tokenIndex
+						if buffer[position] != rune('"') {
+							goto l58
+						}
+						position++
+						goto l57
+					l58:
+						position, tokenIndex = position58, tokenIndex58
+					}
+					if !matchDot() {
+						goto l57
+					}
+					goto l56
+				l57:
+					position, tokenIndex = position57, tokenIndex57
+				}
+				add(ruletext, position55)
+			}
+			return true
+		},
+		/* 15 regex <- <('/' pattern '/' &(space / ')' / !.))> */
+		func() bool {
+			position59, tokenIndex59 := position, tokenIndex
+			{
+				position60 := position
+				if buffer[position] != rune('/') {
+					goto l59
+				}
+				position++
+				if !_rules[rulepattern]() {
+					goto l59
+				}
+				if buffer[position] != rune('/') {
+					goto l59
+				}
+				position++
+				{
+					position61, tokenIndex61 := position, tokenIndex
+					{
+						position62, tokenIndex62 := position, tokenIndex
+						if !_rules[rulespace]() {
+							goto l63
+						}
+						goto l62
+					l63:
+						position, tokenIndex = position62, tokenIndex62
+						if buffer[position] != rune(')') {
+							goto l64
+						}
+						position++
+						goto l62
+					l64:
+						position, tokenIndex = position62, tokenIndex62
+						{
+							position65, tokenIndex65 := position, tokenIndex
+							if !matchDot() {
+								goto l65
+							}
+							goto l59
+						l65:
+							position, tokenIndex = position65, tokenIndex65
+						}
+					}
+				l62:
+					position, tokenIndex = position61, tokenIndex61
+				}
+				add(ruleregex, position60)
+			}
+			return true
+		l59:
+			position, tokenIndex = position59, tokenIndex59
+			return false
+		},
+		/* 16 pattern <- <(('\\' .) / (!'/' .))+> */
+		func() bool {
+			position66, tokenIndex66 := position, tokenIndex
+			{
+				position67 := position
+				{
+					position70, tokenIndex70 := position, tokenIndex
+					if buffer[position] != rune('\\') {
+						goto l71
+					}
+					position++
+					if !matchDot() {
+						goto l71
+					}
+					goto l70
+				l71:
+					position, tokenIndex = position70, tokenIndex70
+					{
+						position72, tokenIndex72 := position, tokenIndex
+						if buffer[position] != rune('/') {
+							goto l72
+						}
+						position++
+						goto l66
+					l72:
+						position, tokenIndex = position72, tokenIndex72
+					}
+					if !matchDot() {
+						goto l66
+					}
+				}
+			l70:
+			l68:
+				{
+					position69, tokenIndex69 := position, tokenIndex
+					{
+						position73, tokenIndex73 := position, tokenIndex
+						if buffer[position] != rune('\\') {
+							goto l74
+						}
+						position++
+						if !matchDot() {
+							goto l74
+						}
+						goto l73
+					l74:
+						position, tokenIndex = position73, tokenIndex73
+						{
+							position75, tokenIndex75 := position, tokenIndex
+							if buffer[position] != rune('/') {
+								goto l75
+							}
+							position++
+							goto l69
+						l75:
+							position, tokenIndex = position75, tokenIndex75
+						}
+						if !matchDot() {
+							goto l69
+						}
+					}
+				l73:
+					goto l68
+				l69:
+					position, tokenIndex = position69, tokenIndex69
+				}
+				add(rulepattern, position67)
+			}
+			return true
+		l66:
+			position, tokenIndex = position66, tokenIndex66
+			return false
+		},
+		/* 17 word <- <(!(keyword (space / ')' / !.)) (!(space / '"' / '(' / ')') .)+)> */
+		func() bool {
+			position76, tokenIndex76 := position, tokenIndex
+			{
+				position77 := position
+				{
+					position78, tokenIndex78 := position, tokenIndex
+					if !_rules[rulekeyword]() {
+						goto l78
+					}
+					{
+						position79, tokenIndex79 := position, tokenIndex
+						if !_rules[rulespace]() {
+							goto l80
+						}
+						goto l79
+					l80:
+						position, tokenIndex = position79, tokenIndex79
+						if buffer[position] != rune(')') {
+							goto l81
+						}
+						position++
+						goto l79
+					l81:
+						position, tokenIndex = position79, tokenIndex79
+						{
+							position82, tokenIndex82 := position, tokenIndex
+							if !matchDot() {
+								goto l82
+							}
+							goto l78
+						l82:
+							position, tokenIndex = position82, tokenIndex82
+						}
+					}
+				l79:
+					goto l76
+				l78:
+					position, tokenIndex = position78, tokenIndex78
+				}
+				{
+					position85, tokenIndex85 := position, tokenIndex
+					{
+						position86, tokenIndex86 := position, tokenIndex
+						if !_rules[rulespace]() {
+							goto l87
+						}
+						goto l86
+					l87:
+						position, tokenIndex = position86, tokenIndex86
+						if buffer[position] != rune('"') {
+							goto l88
+						}
+						position++
+						goto l86
+					l88:
+						position, tokenIndex = position86, tokenIndex86
+						if buffer[position] != rune('(') {
+							goto l89
+						}
+						position++
+						goto l86
+					l89:
+						position, tokenIndex = position86, tokenIndex86
+						if buffer[position] != rune(')') {
+							goto l85
+						}
+						position++
+					}
+				l86:
+					goto l76
+				l85:
+					position, tokenIndex = position85, tokenIndex85
+				}
+				if !matchDot() {
+					goto l76
+				}
+			l83:
+				{
+					position84, tokenIndex84 := position, tokenIndex
+					{
+						position90, tokenIndex90 := position, tokenIndex
+						{
+							position91, tokenIndex91 := position, tokenIndex
+							if !_rules[rulespace]() {
+								goto l92
+							}
+							goto l91
+						l92:
+							position, tokenIndex = position91, tokenIndex91
+							if buffer[position] != rune('"') {
+								goto l93
+							}
+							position++
+							goto l91
+						l93:
+							position, tokenIndex = position91, tokenIndex91
+							if buffer[position] != rune('(') {
+								goto l94
+							}
+							position++
+							goto l91
+						l94:
+							position, tokenIndex = position91, tokenIndex91
+							if buffer[position] != rune(')') {
+								goto l90
+							}
+							position++
+						}
+					l91:
+						goto l84
+					l90:
+						position, tokenIndex = position90, tokenIndex90
+					}
+					if !matchDot() {
+						goto l84
+					}
+					goto l83
+				l84:
+					position, tokenIndex = position84, tokenIndex84
+				}
+				add(ruleword, position77)
+			}
+			return true
+		l76:
+			position, tokenIndex = position76, tokenIndex76
+			return false
+		},
+		/* 18 keyword <- <(('A' 'N' 'D') / ('O' 'R') / ('N' 'O' 'T') / ('N' 'E' 'A' 'R' '/' [0-9]+))> */
+		func() bool {
+			position95, tokenIndex95 := position, tokenIndex
+			{
+				position96 := position
+				{
+					position97, tokenIndex97 := position, tokenIndex
+					if buffer[position] != rune('A') {
+						goto l98
+					}
+					position++
+					if buffer[position] != rune('N') {
+						goto l98
+					}
+					position++
+					if buffer[position] != rune('D') {
+						goto l98
+					}
+					position++
+					goto l97
+				l98:
+					position, tokenIndex = position97, tokenIndex97
+					if buffer[position] != rune('O') {
+						goto l99
+					}
+					position++
+					if buffer[position] != rune('R') {
+						goto l99
+					}
+					position++
+					goto l97
+				l99:
+					position, tokenIndex = position97, tokenIndex97
+					if buffer[position] != rune('N') {
+						goto l100
+					}
+					position++
+					if buffer[position] != rune('O') {
+						goto l100
+					}
+					position++
+					if buffer[position] != rune('T') {
+						goto l100
+					}
+					position++
+					goto l97
+				l100:
+					position, tokenIndex = position97, tokenIndex97
+					if buffer[position] != rune('N') {
+						goto l95
+					}
+					position++
+					if buffer[position] != rune('E') {
+						goto l95
+					}
+					position++
+					if buffer[position] != rune('A') {
+						goto l95
+					}
+					position++
+					if buffer[position] != rune('R') {
+						goto l95
+					}
+					position++
+					if buffer[position] != rune('/') {
+						goto l95
+					}
+					position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l95
+					}
+					position++
+				l101:
+					{
+						position102, tokenIndex102 := position, tokenIndex
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l102
+						}
+						position++
+						goto l101
+					l102:
+						position, tokenIndex = position102, tokenIndex102
+					}
+				}
+			l97:
+				add(rulekeyword, position96)
+			}
+			return true
+		l95:
+			position, tokenIndex = position95, tokenIndex95
+			return false
+		},
+		/* 19 sp <- <space*> */
+		func() bool {
+			{
+				position104 := position
+			l105:
+				{
+					position106, tokenIndex106 := position, tokenIndex
+					if !_rules[rulespace]() {
+						goto l106
+					}
+					goto l105
+				l106:
+					position, tokenIndex = position106, tokenIndex106
+				}
+				add(rulesp, position104)
+			}
+			return true
+		},
+		/* 20 sp1 <- <space+> */
+		func() bool {
+			position107, tokenIndex107 := position, tokenIndex
+			{
+				position108 := position
+				if !_rules[rulespace]() {
+					goto l107
+				}
+			l109:
+				{
+					position110, tokenIndex110 := position, tokenIndex
+					if !_rules[rulespace]() {
+						goto l110
+					}
+					goto l109
+				l110:
+					position, tokenIndex = position110, tokenIndex110
+				}
+				add(rulesp1, position108)
+			}
+			return true
+		l107:
+			position, tokenIndex = position107, tokenIndex107
+			return false
+		},
+		/* 21 space <- <(' ' / '\t' / '\n' / '\r')> */
+		func() bool {
+			position111, tokenIndex111 := position, tokenIndex
+			{
+				position112 := position
+				{
+					position113, tokenIndex113 := position, tokenIndex
+					if buffer[position] != rune(' ') {
+						goto l114
+					}
+					position++
+					goto l113
+				l114:
+					position, tokenIndex = position113, tokenIndex113
+					if buffer[position] != rune('\t') {
+						goto l115
+					}
+					position++
+					goto l113
+				l115:
+					position, tokenIndex = position113, tokenIndex113
+					if buffer[position] != rune('\n') {
+						goto l116
+					}
+					position++
+					goto l113
+				l116:
+					position, tokenIndex = position113, tokenIndex113
+					if buffer[position] != rune('\r') {
+						goto l111
+					}
+					position++
+				}
+			l113:
+				add(rulespace, position112)
+			}
+			return true
+		l111:
+			position, tokenIndex = position111, tokenIndex111
+			return false
+		},
+	}
+	p.rules = _rules
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package query
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	test := func(query, expected string) {
+		tree, err := Parse(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := tree.String(); s != expected {
+			t.Fatal("invalid tree", query, s)
+		}
+	}
+	test(`apple`, `apple`)
+	test(`  apple  `, `apple`)
+	test(`apple tree`, `(apple tree)`)
+	test(`"apple tree"`, `"apple tree"`)
+	test(`+a -b c`, `(+a NOT b c)`)
+	test(`a AND b OR c`, `((a AND b) OR c)`)
+	test(`a OR b AND c`, `(a OR (b AND c))`)
+	test(`a AND NOT b`, `(a AND NOT b)`)
+	test(`(a OR b) c`, `((a OR b) c)`)
+	test(`-(a OR b)`, `NOT (a OR b)`)
+	test(`a NEAR/3 b NEAR/5 c`, `((a NEAR/3 b) NEAR/5 c)`)
+	test(`title:"new york" category:fruits`, `(title:"new york" category:fruits)`)
+	test(`ANDROID ORACLE`, `(ANDROID ORACLE)`)
+	test(`and or`, `(and or)`)
+	test(`/ap+le/ title:/new.*/`, `(/ap+le/ title:/new.*/)`)
+	test(`/a\/b/`, `/a\/b/`)
+	test(`/a b/`, `/a b/`)
+	test(`a/b/c`, `a/b/c`)
+	test(`/apple/tree`, `/apple/tree`)
+
+	tree, err := Parse(`   `)
+	if err != nil {
+		t.Fatal(err)
+	} else if tree != nil {
+		t.Fatal("empty query should have no tree")
+	}
+	for _, query := range []string{`"apple`, `(apple`, `apple NOT`, `apple AND`, `apple)`} {
+		if _, err := Parse(query); err == nil {
+			t.Fatal("invalid query should fail", query)
+		}
+	}
+}
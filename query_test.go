@@ -0,0 +1,174 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pointlander/wikipedia/query"
+)
+
+func TestNewClause(t *testing.T) {
+	analyzer, err := NewAnalyzer(NewAnalysis(DefaultLanguage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test := func(text, expected string) {
+		tree, err := query.Parse(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clause, err := NewClause(analyzer, tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := fmt.Sprint(clause); s != expected {
+			t.Fatal("invalid clause", text, s)
+		}
+	}
+	test(`"New York City"`, `"new york citi"`)
+	test(`capital NEAR/3 "new york"`, `capit NEAR/3 "new york"`)
+	test(`title:"New York"`, `"title:new title:york"`)
+	test(`the NEAR/3 capital`, `capit`)
+	test(`New-York`, `"new york"`)
+	test(`/app.*/`, `/^(?:app.*)$/`)
+	test(`title:/york|city/`, `/^title:(?:york|city)$/`)
+
+	tree, err := query.Parse(`"bank of america"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clause, err := NewClause(analyzer, tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clause.Terms) != 2 || clause.Terms[1].Position != 2 {
+		t.Fatal("stop words should be counted in the positions", clause)
+	}
+}
+
+// testSearch returns a test of the titles of the results of a query
+func testSearch(t *testing.T, encyclopedia *Encyclopedia) func(query string, expected ...string) {
+	return func(query string, expected ...string) {
+		results, err := encyclopedia.Search(SearchRequest{Query: query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		titles := make([]string, 0, len(results.Results))
+		for _, result := range results.Results {
+			titles = append(titles, result.Article.Title)
+		}
+		sort.Strings(titles)
+		if fmt.Sprint(titles) != fmt.Sprint(expected) {
+			t.Fatal("invalid results", query, titles)
+		}
+	}
+}
+
+func TestSearchPhrase(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	test := testSearch(t, encyclopedia)
+	test(`"new york city"`, "Apple", "New York City", "United States", "Washington, D.C.")
+	test(`"new amsterdam"`, "New York City")
+	test(`"york new"`)
+	test(`capital NEAR/2 washington`, "United States")
+	test(`capital NEAR/1 washington`)
+	test(`"largest city" NEAR/7 capital`, "United States")
+	test(`"largest city" NEAR/6 capital`)
+}
+
+func TestSearchBoolean(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	test := testSearch(t, encyclopedia)
+	test(`capital`, "United States", "Washington, D.C.")
+	test(`capital york`, "Apple", "New York City", "United States", "Washington, D.C.")
+	test(`+capital +amsterdam`)
+	test(`+capital york`, "United States", "Washington, D.C.")
+	test(`capital -country`, "Washington, D.C.")
+	test(`capital AND NOT part`, "United States")
+	test(`capital -(country OR part)`)
+	test(`capital AND (fruit OR amsterdam)`)
+	test(`(fruit OR amsterdam) AND york`, "Apple", "New York City")
+	test(`fruit OR amsterdam`, "Apple", "New York City")
+	test(`NOT capital`)
+	test(`the AND fruit`, "Apple")
+	test(`title:york`, "New York City")
+	test(`title:"new york"`, "New York City")
+	test(`title:capital`)
+	test(`category:"north america"`, "United States", "Washington, D.C.")
+	test(`category:cities`, "New York City")
+	test(`category:"state fruits"`)
+
+	_, err := encyclopedia.Search(SearchRequest{Query: `(capital`})
+	if err == nil {
+		t.Fatal("invalid query should fail")
+	}
+}
+
+func TestSearchRegex(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	test := testSearch(t, encyclopedia)
+	test(`c++`)
+	test(`(c++ OR capital)`, "United States", "Washington, D.C.")
+	test(`/capit.*/`, "United States", "Washington, D.C.")
+	results, err := encyclopedia.Search(SearchRequest{Query: `/capita.*/`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results.Corrected || results.Suggestion != `/capital.*/` {
+		t.Fatal("the literal word capita should match nothing", results.Suggestion)
+	}
+	test(`/fruit/`, "Apple")
+
+	encyclopedia.Options.Regex = true
+	test(`/cap.*/`, "United States", "Washington, D.C.")
+	test(`/ap+l/`, "Apple")
+	test(`/fruit|amsterdam/ york`, "Apple", "New York City", "United States", "Washington, D.C.")
+	test(`/.*/ NEAR/0 /amsterdam/`, "New York City")
+	test(`title:/app.*/`, "Apple")
+	test(`title:/.*ppl/`, "Apple")
+	test(`title:/.*ork/`, "New York City")
+	test(`/title.*/`)
+	test(`+/york/ -/fruit/`, "New York City", "United States", "Washington, D.C.")
+
+	for _, text := range []string{`(capital`, `/c++/`, `/(/`, `/a{2000}/`, "/" + strings.Repeat("a", MaxRegexLength+1) + "/"} {
+		_, err := encyclopedia.Search(SearchRequest{Query: text})
+		if _, ok := err.(*QueryError); !ok {
+			t.Fatal("invalid query should fail with a query error", text, err)
+		}
+	}
+
+	limit := MaxRegexTerms
+	defer func() {
+		MaxRegexTerms = limit
+	}()
+	MaxRegexTerms = 2
+	_, err = encyclopedia.Search(SearchRequest{Query: `/.*/`})
+	if _, ok := err.(*QueryError); !ok {
+		t.Fatal("a regular expression matching too many terms should fail", err)
+	}
+
+	scan := MaxRegexScan
+	defer func() {
+		MaxRegexScan = scan
+	}()
+	MaxRegexScan = 8
+	_, err = encyclopedia.Search(SearchRequest{Query: `/.*zzz/`})
+	if _, ok := err.(*QueryError); !ok {
+		t.Fatal("a regular expression reading too many terms should fail", err)
+	}
+	results, err = encyclopedia.Search(SearchRequest{Query: `/york/`})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(results.Results) == 0 {
+		t.Fatal("a regular expression with a literal prefix should be expanded")
+	}
+}
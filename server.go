@@ -5,10 +5,13 @@
 package wikipedia
 
 import (
+	"encoding/json"
+	"fmt"
 	"html/template"
 	"net/http"
 	"net/url"
-	"unicode"
+	"strconv"
+	"strings"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -19,9 +22,30 @@ const IndexPage = `<html>
   <body>
     <h3>Encyclopedia</h3>
     <form action="/wiki/search" method="post">
-      <input type="text" id="query" name="query">
+      <input type="text" id="query" name="query" list="completions" autocomplete="off">
+      <datalist id="completions"></datalist>
       <input type="submit" value="Submit">
     </form>
+    <script>
+      var query = document.getElementById("query");
+      var completions = document.getElementById("completions");
+      query.addEventListener("input", function() {
+        if (query.value.trim() === "") {
+          completions.innerHTML = "";
+          return;
+        }
+        fetch("/wiki/complete?q=" + encodeURIComponent(query.value))
+          .then(function(response) { return response.json(); })
+          .then(function(titles) {
+            completions.innerHTML = "";
+            titles.forEach(function(title) {
+              var option = document.createElement("option");
+              option.value = title;
+              completions.appendChild(option);
+            });
+          });
+      });
+    </script>
   </body>
 </html>
 `
@@ -58,27 +82,75 @@ const EntryTemplate = `<html>
    .tooltip:hover .tooltiptext {
     visibility: visible;
    }
+
+   /* The infobox is a table on the right of the article */
+   .infobox {
+    float: right;
+    clear: right;
+    width: 22em;
+    margin: 0 0 1em 1em;
+    border: 1px solid #a2a9b1;
+    background-color: #f8f9fa;
+    font-size: 88%;
+   }
+   .infobox caption {
+    font-weight: bold;
+    font-size: 125%;
+   }
+   .infobox th {
+    text-align: left;
+    vertical-align: top;
+    padding-right: 0.5em;
+   }
   </style>
+  {{if .Redirect}}<p><i>(Redirected from {{.Redirect}})</i></p>{{end}}
+  {{if .Revision}}<p><small>Revision {{.Revision}} of {{.Timestamp}}{{if .Contributor}} by {{.Contributor}}{{end}}{{if .Comment}} ({{.Comment}}){{end}}{{if not .Verified}} <b>does not match its sha1</b>{{end}}</small></p>{{end}}
   {{noescape .HTML}}
  </body>
 </html>
 `
 
+// MaxPerPage is the maximum number of results of a page of search results
+const MaxPerPage = 100
+
 // ResultsTemplate is the template for search results
 const ResultsTemplate = `<html>
  <head>
   <title>Search results for {{.Title}}</title>
   </head>
   <body>
+{{if .Corrected}}		<p>Showing results for <a href="{{.Suggestion}}">{{.Correction}}</a></p>
+{{else if .Suggestion}}		<p>Did you mean <a href="{{.Suggestion}}">{{.Correction}}</a>?</p>
+{{end}}		<p>Results {{.First}} to {{.Last}} of {{.Total}}</p>
 		<ul>
 {{range .Results}}
-			<li><a href="/wiki/article/{{escape .Article.Title}}">{{.Article.Title}}</a></li>
+			<li><a href="/wiki/article/{{escape .Article.Title}}">{{.Article.Title}}</a>
+{{range .Snippets}}				<p>{{range .}}{{if .Match}}<b>{{.Text}}</b>{{else}}{{.Text}}{{end}}{{end}} ...</p>
+{{end}}			</li>
 {{end}}
 		</ul>
+		<p>
+{{if .Previous}}			<a href="{{.Previous}}">Previous</a>
+{{end}}{{if .Next}}			<a href="{{.Next}}">Next</a>
+{{end}}		</p>
   </body>
  </html>
 `
 
+// DisambiguationTemplate is the template for a title of several articles
+const DisambiguationTemplate = `<html>
+ <head>
+  <title>{{.Title}} (disambiguation)</title>
+ </head>
+ <body>
+  <p>{{.Title}} may refer to:</p>
+  <ul>
+{{range .Titles}}   <li><a href="/wiki/article/{{escape .}}">{{.}}</a></li>
+{{end}}  </ul>
+ </body>
+</html>
+`
+
 // Interface outputs the search interface
 func Interface(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	w.Write([]byte(IndexPage))
@@ -86,31 +158,197 @@ func Interface(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 // Article is the endpoint for view an article
 func (e *Encyclopedia) Article(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	title := ps.ByName("article")
-	runes := []rune(title)
-	runes[0] = unicode.ToUpper(runes[0])
-	title = string(runes)
-	article := e.Lookup(title)
-	err := e.entryTemplate.Execute(w, article)
+	title := strings.TrimSpace(ps.ByName("article"))
+	if title == "" {
+		http.Error(w, "missing article title", http.StatusBadRequest)
+		return
+	}
+	article, err := e.Lookup(title)
+	if ambiguous, ok := err.(*AmbiguousError); ok {
+		w.WriteHeader(http.StatusMultipleChoices)
+		err = e.disambiguationTemplate.Execute(w, ambiguous)
+		if err != nil {
+			return
+		}
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if article == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if article.Redirect != "" {
+		location := "/wiki/article/" + url.PathEscape(article.Title) + "?" +
+			url.Values{"redirect": {article.Redirect}}.Encode()
+		if article.Anchor != "" {
+			location += "#" + url.PathEscape(Anchor(article.Anchor))
+		}
+		http.Redirect(w, r, location, http.StatusFound)
+		return
+	}
+	html, err := e.Render(article)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	type Entry struct {
+		Title       string
+		Redirect    string
+		Revision    uint64
+		Timestamp   string
+		Contributor string
+		Comment     string
+		Verified    bool
+		HTML        string
+	}
+	entry := Entry{
+		Title:       article.Title,
+		Redirect:    r.URL.Query().Get("redirect"),
+		Revision:    article.Revision,
+		Timestamp:   article.Timestamp,
+		Contributor: article.Contributor(),
+		Comment:     article.Comment,
+		Verified:    article.Verify(),
+		HTML:        html,
+	}
+	err = e.entryTemplate.Execute(w, entry)
 	if err != nil {
 		return
 	}
 }
 
+// parseSearch parses the namespaces and the page of a search from a form,
+// pages are numbered from 1
+func (e *Encyclopedia) parseSearch(form url.Values, query string) (SearchRequest, int, error) {
+	namespaces, err := e.ParseNamespaces(strings.Join(form["ns"], ","))
+	if err != nil {
+		return SearchRequest{}, 0, err
+	}
+	number := func(name string, value int) (int, error) {
+		if text := form.Get(name); text != "" {
+			var err error
+			value, err = strconv.Atoi(text)
+			if err != nil || value < 1 {
+				return 0, fmt.Errorf("invalid %s %s", name, text)
+			}
+		}
+		return value, nil
+	}
+	page, err := number("page", 1)
+	if err != nil {
+		return SearchRequest{}, 0, err
+	}
+	perPage, err := number("per_page", DefaultLimit)
+	if err != nil {
+		return SearchRequest{}, 0, err
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+	request := SearchRequest{
+		Query:      query,
+		Namespaces: namespaces,
+		Offset:     (page - 1) * perPage,
+		Limit:      perPage,
+	}
+	return request, page, nil
+}
+
 // WikiSearch searches for articles
 func (e *Encyclopedia) WikiSearch(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	r.ParseForm()
-	query := r.Form["query"][0]
-	results := e.Search(query)
-	type Results struct {
-		Title   string
-		Results []Result
-	}
-	data := Results{
-		Title:   query,
-		Results: results,
-	}
-	err := e.resultsTemplate.Execute(w, data)
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.Form.Get("query")
+	if query == "" {
+		http.Error(w, "missing query", http.StatusBadRequest)
+		return
+	}
+	request, page, err := e.parseSearch(r.Form, query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	namespaces, perPage := request.Namespaces, request.Limit
+	results, err := e.Search(request)
+	if _, ok := err.(*QueryError); ok {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// link returns the link to a page of the results of a query
+	link := func(query string, page int) string {
+		values := url.Values{
+			"query":    {query},
+			"page":     {strconv.Itoa(page)},
+			"per_page": {strconv.Itoa(perPage)},
+		}
+		for _, namespace := range namespaces {
+			values.Add("ns", strconv.Itoa(int(namespace)))
+		}
+		return "/wiki/search?" + values.Encode()
+	}
+	type Page struct {
+		Title              string
+		First, Last, Total int
+		Results            []Result
+		Previous, Next     string
+		Correction         string
+		Suggestion         string
+		Corrected          bool
+	}
+	data := Page{
+		Title:      query,
+		First:      (page-1)*perPage + 1,
+		Last:       (page-1)*perPage + len(results.Results),
+		Total:      results.Total,
+		Results:    results.Results,
+		Correction: results.Suggestion,
+		Corrected:  results.Corrected,
+	}
+	if results.Suggestion != "" {
+		data.Suggestion = link(results.Suggestion, 1)
+	}
+	if len(results.Results) == 0 {
+		data.First = data.Last
+	}
+	if page > 1 {
+		data.Previous = link(query, page-1)
+	}
+	if page*perPage < results.Scored {
+		data.Next = link(query, page+1)
+	}
+	err = e.resultsTemplate.Execute(w, data)
+	if err != nil {
+		return
+	}
+}
+
+// WikiComplete completes the prefix of a title, the titles are returned as json
+func (e *Encyclopedia) WikiComplete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	values := r.URL.Query()
+	n := DefaultCompletions
+	if text := values.Get("n"); text != "" {
+		var err error
+		n, err = strconv.Atoi(text)
+		if err != nil || n < 1 || n > MaxPerPage {
+			http.Error(w, "invalid n "+text, http.StatusBadRequest)
+			return
+		}
+	}
+	titles, err := e.Complete(values.Get("q"), n)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(titles)
 	if err != nil {
 		return
 	}
@@ -125,23 +363,39 @@ func escape(a string) string {
 }
 
 // Server start server mode
-func Server(encyclopedia *Encyclopedia, router *httprouter.Router) {
+func Server(encyclopedia *Encyclopedia, router *httprouter.Router) error {
 	entryTemplate, err := template.New("entry").Funcs(template.FuncMap{
 		"noescape": noescape,
 	}).Parse(EntryTemplate)
 	if err != nil {
-		panic(err)
+		return err
 	}
 	resultsTemplate, err := template.New("entry").Funcs(template.FuncMap{
 		"escape": escape,
 	}).Parse(ResultsTemplate)
 	if err != nil {
-		panic(err)
+		return err
+	}
+
+	disambiguationTemplate, err := template.New("disambiguation").Funcs(template.FuncMap{
+		"escape": escape,
+	}).Parse(DisambiguationTemplate)
+	if err != nil {
+		return err
 	}
 
 	encyclopedia.entryTemplate = entryTemplate
 	encyclopedia.resultsTemplate = resultsTemplate
+	encyclopedia.disambiguationTemplate = disambiguationTemplate
 	router.GET("/wiki", Interface)
 	router.GET("/wiki/article/:article", encyclopedia.Article)
+	router.GET("/wiki/complete", encyclopedia.WikiComplete)
+	router.GET("/wiki/search", encyclopedia.WikiSearch)
 	router.POST("/wiki/search", encyclopedia.WikiSearch)
+	router.GET("/api/v1/article/*title", encyclopedia.APIArticle)
+	router.GET("/api/v1/infobox/*title", encyclopedia.APIInfobox)
+	router.GET("/api/v1/search", encyclopedia.APISearch)
+	router.GET("/api/v1/complete", encyclopedia.APIComplete)
+	router.GET("/api/v1/openapi.json", APIOpenAPI)
+	return nil
 }
@@ -0,0 +1,151 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestServer(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	router := httprouter.New()
+	err := Server(encyclopedia, router)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	if recorder := get("/wiki/article/" + url.PathEscape("new York City")); recorder.Code != http.StatusOK {
+		t.Fatal("article should be found", recorder.Code)
+	} else if !strings.Contains(recorder.Body.String(), "<title>New York City</title>") {
+		t.Fatal("invalid article page", recorder.Body.String())
+	}
+	if recorder := get("/wiki/article/" + url.PathEscape("Zürich")); recorder.Code != http.StatusOK {
+		t.Fatal("article should be found", recorder.Code)
+	} else if body := recorder.Body.String(); !strings.Contains(body, "<table class=\"infobox\">\n<caption>Zürich</caption>") ||
+		!strings.Contains(body, "<tr><th>Population total</th><td>421878</td></tr>") ||
+		!strings.Contains(body, `<td><a href="/wiki/article/Switzerland">Switzerland</a></td>`) ||
+		strings.Contains(body, "{{Infobox") {
+		t.Fatal("invalid infobox", body)
+	}
+	if recorder := get("/wiki/article/" + url.PathEscape("US cities")); recorder.Code != http.StatusFound {
+		t.Fatal("redirect should be followed", recorder.Code)
+	} else if location := recorder.Header().Get("Location"); location != "/wiki/article/United%20States?redirect=US+cities#Cities" {
+		t.Fatal("invalid redirect", location)
+	}
+	if recorder := get("/wiki/article/United%20States?redirect=US+cities"); recorder.Code != http.StatusOK {
+		t.Fatal("article should be found", recorder.Code)
+	} else if body := recorder.Body.String(); !strings.Contains(body, "(Redirected from US cities)") ||
+		!strings.Contains(body, `<h2 id="Cities">Cities</h2>`) ||
+		!strings.Contains(body, "Revision 1031238042 of 2021-06-28T17:41:07Z by Example (copyedit)") ||
+		strings.Contains(body, "does not match its sha1") {
+		t.Fatal("invalid article page", body)
+	}
+	if recorder := get("/wiki/article/united_states"); recorder.Code != http.StatusOK {
+		t.Fatal("normalized title should be found", recorder.Code)
+	} else if !strings.Contains(recorder.Body.String(), "<title>United States</title>") {
+		t.Fatal("invalid article page", recorder.Body.String())
+	}
+	if recorder := get("/wiki/article/usa"); recorder.Code != http.StatusMultipleChoices {
+		t.Fatal("ambiguous title should be disambiguated", recorder.Code)
+	} else if body := recorder.Body.String(); !strings.Contains(body, `<a href="/wiki/article/USA">USA</a>`) ||
+		!strings.Contains(body, `<a href="/wiki/article/Usa">Usa</a>`) {
+		t.Fatal("invalid disambiguation page", body)
+	}
+	if recorder := get("/wiki/article/%20"); recorder.Code != http.StatusBadRequest {
+		t.Fatal("empty title should be a bad request", recorder.Code)
+	}
+	if recorder := get("/wiki/article/Missing"); recorder.Code != http.StatusNotFound {
+		t.Fatal("article should not be found", recorder.Code)
+	}
+	if recorder := post("/wiki/search", url.Values{}); recorder.Code != http.StatusBadRequest {
+		t.Fatal("missing query should be a bad request", recorder.Code)
+	}
+	if recorder := post("/wiki/search", url.Values{"query": {"capital"}}); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	} else if body := recorder.Body.String(); !strings.Contains(body, "Washington, D.C.") ||
+		!strings.Contains(body, "is the <b>capital</b> city of") {
+		t.Fatal("invalid results page", body)
+	}
+	if recorder := post("/wiki/search", url.Values{"query": {"discussion"}, "ns": {"10"}}); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	} else if body := recorder.Body.String(); !strings.Contains(body, "Template:Navbox") || strings.Contains(body, "Talk:United States") {
+		t.Fatal("results should be restricted to the namespace", body)
+	}
+	if recorder := post("/wiki/search", url.Values{"query": {"discussion"}, "ns": {"talk"}}); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	} else if body := recorder.Body.String(); !strings.Contains(body, "Talk:United States") || strings.Contains(body, "Template:Navbox") {
+		t.Fatal("results should be restricted to the namespace by name", body)
+	}
+	if recorder := post("/wiki/search", url.Values{"query": {"discussion"}, "ns": {"nowhere"}}); recorder.Code != http.StatusBadRequest {
+		t.Fatal("invalid namespace should be a bad request", recorder.Code)
+	}
+	if recorder := get("/wiki/search?query=york&per_page=3"); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	} else if body := recorder.Body.String(); !strings.Contains(body, "Results 1 to 3 of 4") ||
+		!strings.Contains(body, `<a href="/wiki/search?page=2&amp;per_page=3&amp;query=york">Next</a>`) ||
+		strings.Contains(body, "Previous") {
+		t.Fatal("invalid first page", body)
+	}
+	if recorder := get("/wiki/search?query=york&per_page=3&page=2"); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	} else if body := recorder.Body.String(); !strings.Contains(body, "Results 4 to 4 of 4") ||
+		!strings.Contains(body, `<a href="/wiki/search?page=1&amp;per_page=3&amp;query=york">Previous</a>`) ||
+		strings.Contains(body, "Next") {
+		t.Fatal("invalid last page", body)
+	}
+	if recorder := get("/wiki/search?query=aple"); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	} else if body := recorder.Body.String(); !strings.Contains(body, `Showing results for <a href="/wiki/search?page=1&amp;per_page=20&amp;query=apple">apple</a>`) ||
+		!strings.Contains(body, "/wiki/article/Apple") {
+		t.Fatal("the corrected query should be answered", body)
+	}
+	if recorder := get("/wiki/search?query=capitl+city"); recorder.Code != http.StatusOK {
+		t.Fatal("search should succeed", recorder.Code)
+	} else if body := recorder.Body.String(); !strings.Contains(body, `Did you mean <a href="/wiki/search?page=1&amp;per_page=20&amp;query=capital&#43;city">capital city</a>?`) {
+		t.Fatal("there should be a suggestion", body)
+	}
+	if recorder := get("/wiki/search?query=york&page=0"); recorder.Code != http.StatusBadRequest {
+		t.Fatal("invalid page should be a bad request", recorder.Code)
+	}
+	if recorder := get("/wiki/complete?q=new"); recorder.Code != http.StatusOK {
+		t.Fatal("completion should succeed", recorder.Code)
+	} else if body := recorder.Body.String(); body != `["New York City"]`+"\n" ||
+		recorder.Header().Get("Content-Type") != "application/json" {
+		t.Fatal("invalid completions", body)
+	}
+	if recorder := get("/wiki/complete?q=xyz"); recorder.Body.String() != "[]\n" {
+		t.Fatal("invalid completions", recorder.Body.String())
+	}
+	if recorder := get("/wiki/complete?q=new&n=0"); recorder.Code != http.StatusBadRequest {
+		t.Fatal("invalid n should be a bad request", recorder.Code)
+	}
+	if recorder := post("/wiki/search", url.Values{"query": {"c++ ("}}); recorder.Code != http.StatusBadRequest {
+		t.Fatal("invalid query should be a bad request", recorder.Code)
+	}
+	encyclopedia.Options.Regex = true
+	if recorder := post("/wiki/search", url.Values{"query": {"/c++/"}}); recorder.Code != http.StatusBadRequest {
+		t.Fatal("invalid regular expression should be a bad request", recorder.Code)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// SnippetWords is the number of words of a snippet
+	SnippetWords = 32
+	// MaxSnippets is the maximum number of snippets of a search result
+	MaxSnippets = 2
+)
+
+var (
+	// refRegex matches a reference and its citation
+	refRegex = regexp.MustCompile(`(?is)<ref\b[^>]*/>|<ref\b[^>]*>.*?(</ref\s*>|$)`)
+	// externalLinkRegex matches an external link, the text of the link is
+	// the first group
+	externalLinkRegex = regexp.MustCompile(`\[(?:[a-zA-Z]+:)?//[^\s\]]*\s*([^\]]*)\]`)
+)
+
+// snippetText returns the plain text of wikitext for snippets, the comments,
+// references and templates are dropped, the external links are their text,
+// and the html entities are decoded
+func snippetText(text string) (string, error) {
+	text = commentRegex.ReplaceAllString(text, "")
+	text = refRegex.ReplaceAllString(text, "")
+	var stripped strings.Builder
+	for _, s := range preprocess(text) {
+		if s.Kind == segmentText {
+			stripped.WriteString(s.Text)
+		}
+	}
+	text = externalLinkRegex.ReplaceAllString(stripped.String(), "$1")
+	plain, err := WikiTextToText(text)
+	if err != nil {
+		return "", err
+	}
+	return html.UnescapeString(plain), nil
+}
+
+// Fragment is a part of the text of a snippet, Match is true if the fragment
+// is a term of the query
+type Fragment struct {
+	Text  string `json:"text"`
+	Match bool   `json:"match,omitempty"`
+}
+
+// Snippet is a passage of the plain text of an article with the terms of a
+// query marked
+type Snippet []Fragment
+
+// String returns the text of the snippet with the terms of the query in
+// between asterisks
+func (s Snippet) String() string {
+	text := ""
+	for _, fragment := range s {
+		if fragment.Match {
+			text += "*" + fragment.Text + "*"
+		} else {
+			text += fragment.Text
+		}
+	}
+	return text
+}
+
+// Snippets returns the passages of the plain text that hold the most of the
+// terms, in the order of the text. The beginning of the text is the passage
+// if none of the terms are in the text.
+func (a *Analyzer) Snippets(text string, terms map[string]bool) []Snippet {
+	text = strings.Join(strings.Fields(text), " ")
+	words := Words(a.Tokenizer, text)
+	if len(words) == 0 {
+		return nil
+	}
+	matches := make([]string, len(words))
+	for i, word := range words {
+		if term, ok := a.Term(word.Text); ok && terms[term] {
+			matches[i] = term
+		}
+	}
+	size := SnippetWords
+	if size > len(words) {
+		size = len(words)
+	}
+
+	// the windows start a quarter of a snippet before the matches, and are
+	// scored by their distinct terms and then by their matches
+	type Window struct {
+		Start, Distinct, Count int
+	}
+	windows, last := make([]Window, 0, 8), -1
+	for i, match := range matches {
+		if match == "" {
+			continue
+		}
+		start := i - size/4
+		if start < 0 {
+			start = 0
+		} else if start > len(words)-size {
+			start = len(words) - size
+		}
+		if start == last {
+			continue
+		}
+		last = start
+		window, distinct := Window{Start: start}, make(map[string]bool)
+		for _, match := range matches[start : start+size] {
+			if match != "" {
+				distinct[match] = true
+				window.Count++
+			}
+		}
+		window.Distinct = len(distinct)
+		windows = append(windows, window)
+	}
+	sort.SliceStable(windows, func(i, j int) bool {
+		if windows[i].Distinct == windows[j].Distinct {
+			return windows[i].Count > windows[j].Count
+		}
+		return windows[i].Distinct > windows[j].Distinct
+	})
+	selected := make([]int, 0, MaxSnippets)
+	for _, window := range windows {
+		if len(selected) == MaxSnippets {
+			break
+		}
+		overlaps := false
+		for _, start := range selected {
+			if window.Start < start+size && start < window.Start+size {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			selected = append(selected, window.Start)
+		}
+	}
+	if len(selected) == 0 {
+		selected = append(selected, 0)
+	}
+	sort.Ints(selected)
+
+	snippets := make([]Snippet, len(selected))
+	for i, start := range selected {
+		snippet, offset := make(Snippet, 0, 8), words[start].Start
+		for j := start; j < start+size; j++ {
+			if matches[j] == "" {
+				continue
+			}
+			if words[j].Start > offset {
+				snippet = append(snippet, Fragment{Text: text[offset:words[j].Start]})
+			}
+			snippet = append(snippet, Fragment{
+				Text:  text[words[j].Start:words[j].End],
+				Match: true,
+			})
+			offset = words[j].End
+		}
+		if end := words[start+size-1].End; end > offset {
+			snippet = append(snippet, Fragment{Text: text[offset:end]})
+		}
+		snippets[i] = snippet
+	}
+	return snippets
+}
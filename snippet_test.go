@@ -0,0 +1,80 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSnippets(t *testing.T) {
+	analyzer, err := NewAnalyzer(NewAnalysis(DefaultLanguage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	filler := func(n int) string {
+		return strings.TrimSpace(strings.Repeat("lorem ", n))
+	}
+	test := func(text string, terms []string, expected ...string) {
+		marked := make(map[string]bool)
+		for _, term := range terms {
+			marked[term] = true
+		}
+		snippets := analyzer.Snippets(text, marked)
+		if fmt.Sprint(snippets) != fmt.Sprint(expected) {
+			t.Fatalf("invalid snippets %q", snippets)
+		}
+	}
+	test("", []string{"appl"})
+	test("Apples grow  on\ntrees.", []string{"appl"}, "*Apples* grow on trees")
+	test("Apples grow on trees.", []string{"pear"}, "Apples grow on trees")
+	test(filler(40)+" apple "+filler(40), []string{"appl"},
+		filler(8)+" *apple* "+filler(23))
+	test(filler(40)+" apple "+filler(40)+" apple tree "+filler(40), []string{"appl", "tree"},
+		filler(8)+" *apple* "+filler(23), filler(8)+" *apple* *tree* "+filler(22))
+	test(filler(40)+" apple "+filler(4)+" apple tree "+filler(40), []string{"appl", "tree"},
+		filler(8)+" *apple* "+filler(4)+" *apple* *tree* "+filler(17))
+	test("apple "+filler(40)+" tree "+filler(40), []string{"appl", "tree"},
+		"*apple* "+filler(31), filler(8)+" *tree* "+filler(23))
+}
+
+func TestSnippetText(t *testing.T) {
+	analyzer, err := NewAnalyzer(NewAnalysis(DefaultLanguage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	article := Article{
+		Title: "France",
+		Text: "{{Short description|Country in Europe}}\n" +
+			"{{Infobox country\n| name = France\n| capital = [[Paris]]\n}}\n" +
+			"<!-- the lead -->'''France'''<ref name=\"a\">{{cite web|title=France}}</ref> is a " +
+			"country&nbsp;in [[Europe]]<ref>Atlas</ref><ref name=\"a\" />, see [http://example.com/france the site].",
+	}
+	plain, err := snippetText(article.Text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snippets := analyzer.Snippets(plain, map[string]bool{"franc": true})
+	if len(snippets) != 1 || snippets[0].String() != "*France* is a country in Europe, see the site" {
+		t.Fatalf("invalid snippets %q", snippets)
+	}
+}
+
+func TestSearchSnippets(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	results, err := encyclopedia.Search(SearchRequest{Query: `capital -country`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 1 || len(results.Results[0].Snippets) != 1 {
+		t.Fatal("there should be a snippet", results)
+	}
+	snippet := results.Results[0].Snippets[0].String()
+	if snippet != "Washington, D.C. is the *capital* city of the United States. It is not a part of any state, unlike New York City" {
+		t.Fatal("invalid snippet", snippet)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pointlander/wikipedia/query"
+
+	"github.com/boltdb/bolt"
+)
+
+const (
+	// MaxEdits is the maximum edit distance of a correction of a word
+	MaxEdits = 2
+	// MaxWordLength is the maximum length in runes of a word of the dictionary
+	MaxWordLength = 64
+)
+
+// Vocabulary returns the distinct words of the text that aren't stop words
+// and are made of letters, they are the words of the spelling dictionary
+func (a *Analyzer) Vocabulary(text string) []string {
+	seen, words := make(map[string]bool), make([]string, 0, 8)
+	for _, word := range a.Tokenizer.Tokenize(text) {
+		if seen[word] || a.StopWords[word] || !dictionary(word) {
+			continue
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+	return words
+}
+
+// dictionary returns true if the word belongs in the spelling dictionary
+func dictionary(word string) bool {
+	length := utf8.RuneCountInString(word)
+	if length < 2 || length > MaxWordLength {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// trigrams returns the trigrams of a word padded with $ on both ends
+func trigrams(word string) []string {
+	runes := []rune("$" + word + "$")
+	grams := make([]string, 0, len(runes)-2)
+	for i := 0; i+3 <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+3]))
+	}
+	return grams
+}
+
+// trigramKey is the key of a word in the trigrams bucket, the trigram is
+// followed by the length of the word in runes and the word so that the words
+// of a trigram are sorted by length
+func trigramKey(gram, word string) []byte {
+	key := make([]byte, 0, len(gram)+1+len(word))
+	key = append(key, gram...)
+	key = append(key, byte(utf8.RuneCountInString(word)))
+	return append(key, word...)
+}
+
+// putWord adds a word to the trigrams bucket if it isn't there already
+func putWord(grams *bolt.Bucket, word string) error {
+	keys := trigrams(word)
+	if grams.Get(trigramKey(keys[0], word)) != nil {
+		return nil
+	}
+	for _, gram := range keys {
+		err := grams.Put(trigramKey(gram, word), []byte{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// levenshtein returns the edit distance between two words
+func levenshtein(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	previous, current := make([]int, len(y)+1), make([]int, len(y)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		current[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if d := previous[j] + 1; d < current[j] {
+				current[j] = d
+			}
+			if d := current[j-1] + 1; d < current[j] {
+				current[j] = d
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(y)]
+}
+
+// correct returns the word of the dictionary that is the fewest edits from
+// the word and whose term is in the index, the more frequent term wins a tie.
+// The correction is empty if no word is within the edits allowed for the
+// length of the word.
+func (a *Analyzer) correct(grams, idx *bolt.Bucket, word string) (string, error) {
+	if grams == nil || !dictionary(word) {
+		return "", nil
+	}
+	length := utf8.RuneCountInString(word)
+	edits := MaxEdits
+	if length <= 4 {
+		edits = 1
+	}
+	low, high := length-edits, length+edits
+	if low < 2 {
+		low = 2
+	}
+
+	// the candidates share trigrams with the word, and an edit changes at
+	// most 3 trigrams
+	keys := trigrams(word)
+	shared := make(map[string]int)
+	for _, gram := range keys {
+		cursor := grams.Cursor()
+		prefix := []byte(gram)
+		for key, _ := cursor.Seek(append(prefix, byte(low))); key != nil && bytes.HasPrefix(key, prefix); key, _ = cursor.Next() {
+			if int(key[len(prefix)]) > high {
+				break
+			}
+			shared[string(key[len(prefix)+1:])]++
+		}
+	}
+	best, distance, frequency := "", edits+1, 0
+	for candidate, count := range shared {
+		if count < len(keys)-3*edits || candidate == word {
+			continue
+		}
+		d := levenshtein(word, candidate)
+		if d > edits || d > distance {
+			continue
+		}
+		term, ok := a.Term(candidate)
+		if !ok {
+			continue
+		}
+		index, err := getIndex(idx, term)
+		if err != nil {
+			return "", err
+		}
+		df := len(index.Indexes)
+		if df == 0 {
+			continue
+		}
+		if d < distance || df > frequency || (df == frequency && candidate < best) {
+			best, distance, frequency = candidate, d, df
+		}
+	}
+	return best, nil
+}
+
+// corrections returns a copy of the syntax tree of a query with the words of
+// the terms that have no postings corrected, and the text of the query with
+// the same corrections. The tree is nil if no word is corrected. Terms of
+// fields and regular expressions are left as they are.
+func (a *Analyzer) corrections(grams *bolt.Bucket, cache *termCache, tree *query.Node, text string) (*query.Node, string, error) {
+	corrected, changed := make(map[string]string), false
+	var correct func(node *query.Node) (*query.Node, error)
+	correct = func(node *query.Node) (*query.Node, error) {
+		copy := *node
+		if node.Operator == query.OperatorTerm {
+			if node.Field != "" || node.Regex {
+				return &copy, nil
+			}
+			for _, word := range a.Tokenizer.Tokenize(node.Text) {
+				if _, has := corrected[word]; has {
+					continue
+				}
+				corrected[word] = ""
+				term, ok := a.Term(word)
+				if !ok {
+					continue
+				}
+				positions, err := cache.get(term)
+				if err != nil {
+					return nil, err
+				} else if len(positions) > 0 {
+					continue
+				}
+				correction, err := a.correct(grams, cache.idx, word)
+				if err != nil {
+					return nil, err
+				}
+				corrected[word] = correction
+				changed = changed || correction != ""
+			}
+			copy.Text = a.replace(node.Text, corrected)
+			return &copy, nil
+		}
+		copy.Children = make([]*query.Node, len(node.Children))
+		for i, child := range node.Children {
+			var err error
+			copy.Children[i], err = correct(child)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &copy, nil
+	}
+	tree, err := correct(tree)
+	if err != nil || !changed {
+		return nil, "", err
+	}
+	return tree, a.replace(text, corrected), nil
+}
+
+// replace replaces the words of the text that have corrections
+func (a *Analyzer) replace(text string, corrected map[string]string) string {
+	output, last := strings.Builder{}, 0
+	for _, word := range Words(a.Tokenizer, text) {
+		correction := corrected[word.Text]
+		if correction == "" {
+			continue
+		}
+		if first, _ := utf8.DecodeRuneInString(text[word.Start:]); unicode.IsUpper(first) {
+			first, size := utf8.DecodeRuneInString(correction)
+			correction = string(unicode.ToUpper(first)) + correction[size:]
+		}
+		output.WriteString(text[last:word.Start])
+		output.WriteString(correction)
+		last = word.End
+	}
+	output.WriteString(text[last:])
+	return output.String()
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	test := func(a, b string, expected int) {
+		if d := levenshtein(a, b); d != expected {
+			t.Fatal("invalid distance", a, b, d)
+		}
+	}
+	test("", "", 0)
+	test("apple", "", 5)
+	test("apple", "apple", 0)
+	test("aple", "apple", 1)
+	test("apel", "apple", 2)
+	test("kitten", "sitting", 3)
+	test("zürich", "zurich", 1)
+}
+
+func TestTrigrams(t *testing.T) {
+	if grams := strings.Join(trigrams("apple"), "|"); grams != "$ap|app|ppl|ple|le$" {
+		t.Fatal("invalid trigrams", grams)
+	}
+	if grams := strings.Join(trigrams("東京"), "|"); grams != "$東京|東京$" {
+		t.Fatal("invalid trigrams", grams)
+	}
+}
+
+func TestSearchSpelling(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	test := func(query, suggestion string, corrected bool, title string) {
+		results, err := encyclopedia.Search(SearchRequest{Query: query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if results.Suggestion != suggestion || results.Corrected != corrected {
+			t.Fatal("invalid suggestion", query, results.Suggestion, results.Corrected)
+		}
+		found := false
+		for _, result := range results.Results {
+			found = found || result.Article.Title == title
+		}
+		if title != "" && !found {
+			t.Fatal("article should be found", query, title)
+		} else if title == "" && len(results.Results) != 0 {
+			t.Fatal("no article should be found", query)
+		}
+	}
+	test("aple", "apple", true, "Apple")
+	test("apel", "", false, "")
+	test("appel", "apple", true, "Apple")
+	test("Swizerland", "Switzerland", true, "Zürich")
+	test(`"capitl city"`, `"capital city"`, true, "Washington, D.C.")
+	test("capitl city", "capital city", false, "New York City")
+	test("capital", "", false, "Washington, D.C.")
+	test("title:capitl", "", false, "")
+	test("zzzz", "", false, "")
+
+	results, err := encyclopedia.Search(SearchRequest{Query: "aple", Exact: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Suggestion != "" || results.Corrected || len(results.Results) != 0 {
+		t.Fatal("exact query should not be corrected", results.Suggestion)
+	}
+}
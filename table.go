@@ -0,0 +1,78 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TableAttributes are the attributes of tables, captions, rows and cells that
+// are kept when wikitext is converted to html
+var TableAttributes = map[string]bool{
+	"abbr":        true,
+	"align":       true,
+	"bgcolor":     true,
+	"border":      true,
+	"cellpadding": true,
+	"cellspacing": true,
+	"class":       true,
+	"colspan":     true,
+	"dir":         true,
+	"headers":     true,
+	"height":      true,
+	"id":          true,
+	"lang":        true,
+	"nowrap":      true,
+	"rowspan":     true,
+	"scope":       true,
+	"style":       true,
+	"summary":     true,
+	"title":       true,
+	"valign":      true,
+	"width":       true,
+}
+
+// attributeRegex matches an html attribute and its double quoted, single
+// quoted or unquoted value
+var attributeRegex = regexp.MustCompile(`([a-zA-Z][-a-zA-Z0-9_:.]*)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"']+)))?`)
+
+// unsafeStyles are the parts of a style that can load urls or run scripts
+var unsafeStyles = []string{"url(", "image(", "image-set(", "expression", "javascript:", "behavior", "-moz-binding", "@import", "\\", "/*"}
+
+// sanitizeAttributes returns the attributes of the text that are in
+// TableAttributes as html. The values are escaped, the spans must be numbers,
+// and the styles that could load urls or run scripts are dropped.
+func sanitizeAttributes(text string) string {
+	attributes, seen := "", make(map[string]bool)
+	for _, match := range attributeRegex.FindAllStringSubmatch(text, -1) {
+		name, value := strings.ToLower(match[1]), strings.TrimSpace(match[2]+match[3]+match[4])
+		if !TableAttributes[name] || seen[name] {
+			continue
+		}
+		switch name {
+		case "colspan", "rowspan":
+			if span, err := strconv.Atoi(value); err != nil || span < 0 {
+				continue
+			}
+		case "style":
+			style, safe := strings.ToLower(value), true
+			for _, unsafe := range unsafeStyles {
+				if strings.Contains(style, unsafe) {
+					safe = false
+				}
+			}
+			if !safe {
+				continue
+			}
+		}
+		seen[name] = true
+		attributes += fmt.Sprintf(" %s=\"%s\"", name, template.HTMLEscapeString(value))
+	}
+	return attributes
+}
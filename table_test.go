@@ -0,0 +1,44 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"testing"
+)
+
+func TestSanitizeAttributes(t *testing.T) {
+	test := func(text, target string) {
+		if attributes := sanitizeAttributes(text); attributes != target {
+			t.Fatalf("not equal %q %q", text, attributes)
+		}
+	}
+	test(`class="wikitable sortable" BORDER=1 align='center'`, ` class="wikitable sortable" border="1" align="center"`)
+	test(`onclick="alert(1)" id="a" ID="b"`, ` id="a"`)
+	test(`colspan="2" rowspan="x"`, ` colspan="2"`)
+	test(`style="color:red" title="a<b"`, ` style="color:red" title="a&lt;b"`)
+	test(`style="background:URL(x.png)"`, "")
+	test(`style="width:expression(alert(1))"`, "")
+	test(`nowrap`, ` nowrap=""`)
+}
+
+func TestWikiTextToHTMLTables(t *testing.T) {
+	test := func(text, target string) {
+		html, err := WikiTextToHTML(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if html != target {
+			t.Fatalf("not equal %q %q", text, html)
+		}
+	}
+	test("{| class=\"wikitable\" onclick=\"x()\"\n|+ The ''caption''\n|-\n! A !! B\n|- style=\"color:red\"\n| [[Paris]] || ''two\n| colspan=\"2\" style=\"background:url(x)\" | three\n|}",
+		"<table class=\"wikitable\">\n<caption>The <i>caption</i></caption>\n<tr>\n<th>A</th>\n<th>B</th>\n</tr>\n"+
+			"<tr style=\"color:red\">\n<td><a href=\"/wiki/article/Paris\">Paris</a></td>\n<td><i>two</i></td>\n<td colspan=\"2\">three</td>\n</tr>\n</table>\n")
+	test("{|\n| a\n{|\n| nested\n|}\n| b\n|}",
+		"<table>\n<tr>\n<td>a\n<table>\n<tr>\n<td>nested</td>\n</tr>\n</table></td>\n<td>b</td>\n</tr>\n</table>\n")
+	test("text\n{| border=1\n|a||b\n|-\n|c\nmore c\n|}\nafter",
+		"text\n<table border=\"1\">\n<tr>\n<td>a</td>\n<td>b</td>\n</tr>\n<tr>\n<td>c\nmore c</td>\n</tr>\n</table>\n\nafter")
+	test("{|\n|x", "<table>\n<tr>\n<td>x</td>\n</tr>\n</table>\n")
+}
@@ -0,0 +1,137 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+)
+
+// Tokenizer splits text into the terms of the index
+type Tokenizer interface {
+	Tokenize(text string) []string
+}
+
+// WordTokenizer is a tokenizer that reports where the words are in the text
+type WordTokenizer interface {
+	Tokenizer
+	// Words splits the text into words and reports where they are in the text
+	Words(text string) []Word
+}
+
+// Word is a word of a text and its byte offsets in the text
+type Word struct {
+	Text       string
+	Start, End int
+}
+
+// Words splits the text into words with the tokenizer. The words of a
+// tokenizer that isn't a WordTokenizer are found in the text by a case
+// insensitive search, and a word that isn't found is empty at the end of the
+// word before it.
+func Words(tokenizer Tokenizer, text string) []Word {
+	if words, ok := tokenizer.(WordTokenizer); ok {
+		return words.Words(text)
+	}
+	tokens := tokenizer.Tokenize(text)
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		lower = text
+	}
+	words, offset := make([]Word, len(tokens)), 0
+	for i, token := range tokens {
+		start, end := offset, offset
+		if j := strings.Index(lower[offset:], strings.ToLower(token)); j >= 0 && token != "" {
+			start = offset + j
+			end = start + len(token)
+		}
+		words[i] = Word{Text: token, Start: start, End: end}
+		offset = end
+	}
+	return words
+}
+
+// UnicodeTokenizer splits text into words following the unicode word
+// boundary rules and case folds them. Han and Hiragana characters are words of
+// their own, and markup punctuation always splits words.
+type UnicodeTokenizer struct{}
+
+// ideograph returns true if the rune is a word of its own
+func ideograph(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana)
+}
+
+// letter returns true if the rune is part of a word
+func letter(r rune) bool {
+	return unicode.In(r, unicode.L, unicode.M, unicode.Nd, unicode.Nl) && !ideograph(r)
+}
+
+// joins returns true if the punctuation joins the runes before and after it
+// into a single word, such as "can't", "3.14" or "1,000"
+func joins(before, punctuation, after rune) bool {
+	digits := unicode.IsDigit(before) && unicode.IsDigit(after)
+	switch punctuation {
+	case '\'', '’', '.':
+		return digits || (unicode.IsLetter(before) && unicode.IsLetter(after))
+	case ',', ';':
+		return digits
+	}
+	return false
+}
+
+// Tokenize splits the text into case folded words
+func (u UnicodeTokenizer) Tokenize(text string) []string {
+	words := u.Words(text)
+	tokens := make([]string, len(words))
+	for i, word := range words {
+		tokens[i] = word.Text
+	}
+	return tokens
+}
+
+// Words splits the text into case folded words
+func (u UnicodeTokenizer) Words(text string) []Word {
+	fold, runes := cases.Fold(), []rune(text)
+	// offsets are the byte offsets of the runes
+	offsets, offset := make([]int, len(runes)+1), 0
+	for i, r := range runes {
+		offsets[i] = offset
+		offset += utf8.RuneLen(r)
+	}
+	offsets[len(runes)] = len(text)
+	tokens := make([]Word, 0, 8)
+	word := func(i, j int) Word {
+		return Word{
+			Text:  fold.String(string(runes[i:j])),
+			Start: offsets[i],
+			End:   offsets[j],
+		}
+	}
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if ideograph(r) {
+			tokens = append(tokens, word(i, i+1))
+			continue
+		} else if !letter(r) {
+			continue
+		}
+		j := i + 1
+		for j < len(runes) {
+			if letter(runes[j]) {
+				j++
+			} else if j+1 < len(runes) && letter(runes[j+1]) && joins(runes[j-1], runes[j], runes[j+1]) {
+				j += 2
+			} else {
+				break
+			}
+		}
+		tokens = append(tokens, word(i, j))
+		i = j - 1
+	}
+	return tokens
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnicodeTokenizer(t *testing.T) {
+	test := func(text, expected string) {
+		tokens := UnicodeTokenizer{}.Tokenize(text)
+		if strings.Join(tokens, "|") != expected {
+			t.Fatal("invalid tokens", text, tokens)
+		}
+	}
+	test("'''Zürich''' is in [[Switzerland]].", "zürich|is|in|switzerland")
+	test("ZÜRICH Straße", "zürich|strasse")
+	test("Москва and 1984", "москва|and|1984")
+	test("東京タワー", "東|京|タワー")
+	test("it's 3.14 or 1,000; U.S.", "it's|3.14|or|1,000|u.s")
+	test("[[Category:Cities]] a_b", "category|cities|a|b")
+	test("", "")
+}
+
+func TestUnicodeTokenizerWords(t *testing.T) {
+	text := "'''Zürich''' is 東京."
+	words := UnicodeTokenizer{}.Words(text)
+	expected := []string{"Zürich", "is", "東", "京"}
+	if len(words) != len(expected) {
+		t.Fatal("invalid words", words)
+	}
+	for i, word := range words {
+		if text[word.Start:word.End] != expected[i] {
+			t.Fatal("invalid offsets", word, text[word.Start:word.End])
+		}
+	}
+}
+
+// fieldsTokenizer is a tokenizer without the offsets of the words
+type fieldsTokenizer struct{}
+
+// Tokenize splits the text into lower case words at white space
+func (fieldsTokenizer) Tokenize(text string) []string {
+	return strings.Fields(strings.ToLower(text))
+}
+
+func TestWords(t *testing.T) {
+	text := "Zürich is in Switzerland"
+	words := Words(fieldsTokenizer{}, text)
+	expected := []string{"Zürich", "is", "in", "Switzerland"}
+	if len(words) != len(expected) {
+		t.Fatal("invalid words", words)
+	}
+	for i, word := range words {
+		if text[word.Start:word.End] != expected[i] {
+			t.Fatal("invalid offsets", word, text[word.Start:word.End])
+		}
+	}
+
+	analyzer := &Analyzer{Tokenizer: fieldsTokenizer{}}
+	snippets := analyzer.Snippets(text, map[string]bool{"switzerland": true})
+	if len(snippets) != 1 || snippets[0].String() != "Zürich is in *Switzerland*" {
+		t.Fatal("invalid snippets", snippets)
+	}
+	if corrected := analyzer.replace("Zurich is", map[string]string{"zurich": "zürich"}); corrected != "Zürich is" {
+		t.Fatal("invalid replacement", corrected)
+	}
+}
+
+func TestSearchUnicode(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	for _, query := range []string{"ZÜRICH", "1984", "東京"} {
+		results, err := encyclopedia.Search(SearchRequest{Query: query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results.Results) != 1 || results.Results[0].Article.Title != "Zürich" {
+			t.Fatal("article should be found", query, len(results.Results))
+		}
+	}
+}
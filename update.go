@@ -0,0 +1,441 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/boltdb/bolt"
+)
+
+// Change is a change to a posting list, the removed articles are removed
+// before the added postings are added
+type Change struct {
+	Add    []Posting
+	Remove []uint32
+}
+
+// Patch applies the change to postings with ascending article indexes
+func (c *Change) Patch(postings []Posting) []Posting {
+	remove := make(map[uint32]bool, len(c.Remove)+len(c.Add))
+	for _, index := range c.Remove {
+		remove[index] = true
+	}
+	for _, posting := range c.Add {
+		remove[posting.Index] = true
+	}
+	patched := make([]Posting, 0, len(postings)+len(c.Add))
+	for _, posting := range postings {
+		if !remove[posting.Index] {
+			patched = append(patched, posting)
+		}
+	}
+	patched = append(patched, c.Add...)
+	sort.SliceStable(patched, func(i, j int) bool {
+		return patched[i].Index < patched[j].Index
+	})
+	unique := patched[:0]
+	for _, posting := range patched {
+		if tail := len(unique) - 1; tail >= 0 && unique[tail].Index == posting.Index {
+			unique[tail] = posting
+			continue
+		}
+		unique = append(unique, posting)
+	}
+	return unique
+}
+
+// Update updates the db from a newer dump: changed articles are replaced, new
+// articles are added, and articles missing from the dump are deleted unless
+// the dump only holds changes. The posting lists of the index are patched so
+// that search stays correct.
+func Update(options Options) error {
+	encyclopedia, err := Open(options)
+	if err != nil {
+		return err
+	}
+	defer encyclopedia.Close()
+	db, options := encyclopedia.DB, encyclopedia.Options
+	statistics, err := encyclopedia.Statistics()
+	if err != nil {
+		return err
+	}
+
+	siteinfo, err := ReadSiteinfo(options.Dump)
+	if err != nil {
+		return err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		err := putAnalysis(tx, options.Analysis)
+		if err != nil {
+			return err
+		}
+		return putNamespaces(tx, siteinfo)
+	})
+	if err != nil {
+		return err
+	}
+
+	input, stop, decoded := make(chan Page, 8), make(chan struct{}), make(chan error, 1)
+	defer close(stop)
+	go func() {
+		decoded <- Pages(options, Checkpoint{}, input, stop)
+	}()
+
+	changes, seen, kept := make(map[string]*Change), make(map[string]bool), make(map[string]bool)
+	change := func(word string) *Change {
+		c := changes[word]
+		if c == nil {
+			c = &Change{}
+			changes[word] = c
+		}
+		return c
+	}
+	apply := func(idx *bolt.Bucket) error {
+		for word, c := range changes {
+			index, err := getIndex(idx, word)
+			if err != nil {
+				return err
+			}
+			postings := c.Patch(DecodeIndex(index))
+			if len(postings) == 0 {
+				err = idx.Delete(indexKey(word))
+			} else {
+				err = putIndex(idx, word, EncodeIndex(postings))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		changes = make(map[string]*Change)
+		return nil
+	}
+	remove := func(tx *bolt.Tx, title []byte) error {
+		wiki := tx.Bucket([]byte("wiki"))
+		pages := tx.Bucket([]byte("pages"))
+		documents := tx.Bucket([]byte("documents"))
+		ranks := tx.Bucket([]byte("ranks"))
+		backlinks := tx.Bucket([]byte("backlinks"))
+		value := wiki.Get(title)
+		key := make([]byte, len(value))
+		copy(key, value)
+		article, err := getArticle(pages, key)
+		if err != nil {
+			return err
+		}
+		if article != nil {
+			index := binary.LittleEndian.Uint32(key)
+			terms, _ := Terms(encyclopedia.Analyzer, article.Title, article.Text)
+			for word := range terms {
+				c := change(word)
+				c.Remove = append(c.Remove, index)
+			}
+		}
+		document, err := getDocument(documents, key)
+		if err != nil {
+			return err
+		}
+		if statistics.Documents > 0 {
+			statistics.Documents--
+		}
+		statistics.Length -= uint64(document.Length)
+		err = pages.Delete(key)
+		if err != nil {
+			return err
+		}
+		err = documents.Delete(key)
+		if err != nil {
+			return err
+		}
+		if ranks != nil {
+			err = ranks.Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		if backlinks != nil {
+			err = backlinks.Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		err = tx.Bucket([]byte("titles")).Delete(titleKey(string(title)))
+		if err != nil {
+			return err
+		}
+		return wiki.Delete(title)
+	}
+	update := func(tx *bolt.Tx, entry Entry) error {
+		if entry.Err != nil {
+			return entry.Err
+		}
+		wiki := tx.Bucket([]byte("wiki"))
+		titles := tx.Bucket([]byte("titles"))
+		pages := tx.Bucket([]byte("pages"))
+		documents := tx.Bucket([]byte("documents"))
+		grams := tx.Bucket([]byte("trigrams"))
+		redirects := tx.Bucket([]byte("redirects"))
+		seen[entry.Title] = true
+		if entry.Redirect != "" {
+			if wiki.Get([]byte(entry.Title)) != nil {
+				err := remove(tx, []byte(entry.Title))
+				if err != nil {
+					return err
+				}
+			}
+			err := titles.Put(titleKey(entry.Title), []byte{})
+			if err != nil {
+				return err
+			}
+			return redirects.Put([]byte(entry.Title), []byte(entry.Redirect))
+		}
+		if redirects.Get([]byte(entry.Title)) != nil {
+			err := redirects.Delete([]byte(entry.Title))
+			if err != nil {
+				return err
+			}
+			err = titles.Delete(titleKey(entry.Title))
+			if err != nil {
+				return err
+			}
+		}
+		for _, word := range entry.Words {
+			err := putWord(grams, word)
+			if err != nil {
+				return err
+			}
+		}
+		if value := wiki.Get([]byte(entry.Title)); value != nil {
+			key := make([]byte, len(value))
+			copy(key, value)
+			if bytes.Equal(pages.Get(key), entry.Value) {
+				return nil
+			}
+			article, err := getArticle(pages, key)
+			if err != nil {
+				return err
+			}
+			// the postings of the terms are replaced as their positions
+			// might have changed
+			index := binary.LittleEndian.Uint32(key)
+			if article != nil {
+				terms, _ := Terms(encyclopedia.Analyzer, article.Title, article.Text)
+				for word := range terms {
+					if _, has := entry.Terms[word]; !has {
+						c := change(word)
+						c.Remove = append(c.Remove, index)
+					}
+				}
+			}
+			for term, positions := range entry.Terms {
+				c := change(term)
+				c.Add = append(c.Add, Posting{
+					Index:     index,
+					Positions: positions,
+				})
+			}
+			document, err := getDocument(documents, key)
+			if err != nil {
+				return err
+			}
+			statistics.Length += uint64(entry.Length) - uint64(document.Length)
+			err = putDocument(documents, key, &Document{
+				Namespace: entry.Namespace,
+				Length:    entry.Length,
+			})
+			if err != nil {
+				return err
+			}
+			return pages.Put(key, entry.Value)
+		}
+
+		index, err := wiki.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 4)
+		binary.LittleEndian.PutUint32(key, uint32(index))
+		err = wiki.Put([]byte(entry.Title), key)
+		if err != nil {
+			return err
+		}
+		err = titles.Put(titleKey(entry.Title), key)
+		if err != nil {
+			return err
+		}
+		err = pages.Put(key, entry.Value)
+		if err != nil {
+			return err
+		}
+		err = putDocument(documents, key, &Document{
+			Namespace: entry.Namespace,
+			Length:    entry.Length,
+		})
+		if err != nil {
+			return err
+		}
+		statistics.Documents++
+		statistics.Length += uint64(entry.Length)
+		for term, positions := range entry.Terms {
+			c := change(term)
+			c.Add = append(c.Add, Posting{
+				Index:     uint32(index),
+				Positions: positions,
+			})
+		}
+		return nil
+	}
+
+	pending, done := make([]chan Entry, 0, NumCPU), false
+	for !done {
+		err = db.Update(func(tx *bolt.Tx) error {
+			for _, bucket := range []string{"wiki", "titles", "pages", "documents", "trigrams", "redirects", "templates"} {
+				_, err := tx.CreateBucketIfNotExists([]byte(bucket))
+				if err != nil {
+					return err
+				}
+			}
+			idx, err := tx.CreateBucketIfNotExists([]byte("index"))
+			if err != nil {
+				return err
+			}
+			next := func() error {
+				entry := <-pending[0]
+				pending = pending[1:]
+				return update(tx, entry)
+			}
+
+			updated, full := 0, false
+			for !full {
+				page, ok := <-input
+				if !ok {
+					for len(pending) > 0 {
+						err := next()
+						if err != nil {
+							return err
+						}
+					}
+					err := <-decoded
+					if err != nil {
+						return err
+					}
+					done = true
+					break
+				}
+				if page.Namespace == TemplateNamespace && len(page.Text) > 0 {
+					kept[string(templateKey(page.Title))] = true
+					err := putTemplate(tx.Bucket([]byte("templates")), page)
+					if err != nil {
+						return err
+					}
+				}
+				if len(page.Text) == 0 || !options.Included(page.Namespace) {
+					continue
+				}
+				if len(pending) >= NumCPU {
+					err := next()
+					if err != nil {
+						return err
+					}
+					updated++
+				}
+				entries := make(chan Entry, 1)
+				go func(page Page) {
+					entries <- prepare(encyclopedia.Analyzer, page)
+				}(page)
+				pending = append(pending, entries)
+
+				var m runtime.MemStats
+				runtime.ReadMemStats(&m)
+				alloc := float64(m.Alloc) / float64(1024*1024*1024)
+				full = alloc > options.Memory || updated >= options.Checkpoint
+			}
+			meta, err := tx.CreateBucketIfNotExists([]byte("meta"))
+			if err != nil {
+				return err
+			}
+			err = statistics.save(meta)
+			if err != nil {
+				return err
+			}
+			return apply(idx)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		wiki := tx.Bucket([]byte("wiki"))
+		idx := tx.Bucket([]byte("index"))
+		redirects := tx.Bucket([]byte("redirects"))
+		meta, err := tx.CreateBucketIfNotExists([]byte("meta"))
+		if err != nil {
+			return err
+		}
+		err = meta.Put([]byte("dump"), []byte(filepath.Base(options.Dump)))
+		if err != nil {
+			return err
+		}
+		if options.Changes {
+			return nil
+		}
+
+		deleted := func(bucket *bolt.Bucket) [][]byte {
+			titles := make([][]byte, 0, 8)
+			cursor := bucket.Cursor()
+			for title, _ := cursor.First(); title != nil; title, _ = cursor.Next() {
+				if !seen[string(title)] {
+					key := make([]byte, len(title))
+					copy(key, title)
+					titles = append(titles, key)
+				}
+			}
+			return titles
+		}
+		for _, title := range deleted(wiki) {
+			err := remove(tx, title)
+			if err != nil {
+				return err
+			}
+		}
+		for _, title := range deleted(redirects) {
+			err := redirects.Delete(title)
+			if err != nil {
+				return err
+			}
+			err = tx.Bucket([]byte("titles")).Delete(titleKey(string(title)))
+			if err != nil {
+				return err
+			}
+		}
+		templates := tx.Bucket([]byte("templates"))
+		removed := make([][]byte, 0, 8)
+		err = templates.ForEach(func(key, value []byte) error {
+			if !kept[string(key)] {
+				removed = append(removed, append([]byte{}, key...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range removed {
+			err := templates.Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		err = statistics.save(meta)
+		if err != nil {
+			return err
+		}
+		return apply(idx)
+	})
+}
@@ -0,0 +1,181 @@
+// Copyright 2021 The Wikipedia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wikipedia
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestChangePatch(t *testing.T) {
+	change := Change{
+		Add:    []Posting{{Index: 7, Positions: []uint32{4}}, {Index: 2, Positions: []uint32{1, 3}}},
+		Remove: []uint32{3, 9},
+	}
+	patched := change.Patch([]Posting{{Index: 1}, {Index: 3}, {Index: 5}, {Index: 7, Positions: []uint32{8}}})
+	target := []Posting{{Index: 1}, {Index: 2, Positions: []uint32{1, 3}}, {Index: 5}, {Index: 7, Positions: []uint32{4}}}
+	if !reflect.DeepEqual(patched, target) {
+		t.Fatal("invalid patch", patched)
+	}
+	target[0].Positions, target[2].Positions = []uint32{0, 5, 6}, []uint32{2}
+	decoded := DecodeIndex(EncodeIndex(target))
+	if !reflect.DeepEqual(decoded, target) {
+		t.Fatal("invalid encoding", decoded)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	titles := func(encyclopedia *Encyclopedia, query string) []string {
+		results, err := encyclopedia.Search(SearchRequest{Query: query, Exact: true})
+		if err != nil {
+			t.Fatal(err)
+		} else if results.Corrected || results.Suggestion != "" {
+			t.Fatal("exact query should not be corrected", query)
+		}
+		titles := make([]string, 0, len(results.Results))
+		for _, result := range results.Results {
+			titles = append(titles, result.Article.Title)
+		}
+		sort.Strings(titles)
+		return titles
+	}
+	equal := func(a, b []string) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	test := func(changes bool) {
+		dir, err := ioutil.TempDir("", "wikipedia")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		options := testOptions(dir)
+		err = Build(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		options.Dump = filepath.Join("testdata", "pages-articles-update.xml.bz2")
+		options.Changes = changes
+		err = Update(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encyclopedia, err := Open(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer encyclopedia.Close()
+		article, err := encyclopedia.Lookup("Boston")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if article == nil {
+			t.Fatal("new article should be found")
+		}
+		article, err = encyclopedia.Lookup("Washington, D.C.")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changes && article == nil {
+			t.Fatal("article should not be deleted")
+		} else if !changes && article != nil {
+			t.Fatal("article should be deleted")
+		}
+		completions, err := encyclopedia.Complete("boston", 0)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(completions) != 1 || completions[0] != "Boston" {
+			t.Fatal("new article should be completed", completions)
+		}
+		completions, err = encyclopedia.Complete("washington", 0)
+		if err != nil {
+			t.Fatal(err)
+		} else if changes != (len(completions) == 1) {
+			t.Fatal("deleted article should not be completed", completions)
+		}
+
+		article, err = encyclopedia.Lookup("US cities")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if changes && article == nil {
+			t.Fatal("redirect should not be deleted")
+		} else if !changes && article != nil {
+			t.Fatal("redirect should be deleted")
+		}
+		article, err = encyclopedia.Lookup("America")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if article == nil || article.Title != "United States" {
+			t.Fatal("redirect should be followed")
+		}
+
+		capital := []string{"Boston", "United States"}
+		if changes {
+			capital = []string{"Boston", "United States", "Washington, D.C."}
+		}
+		if found := titles(encyclopedia, "capital"); !equal(found, capital) {
+			t.Fatal("invalid results", found)
+		}
+		if found := titles(encyclopedia, "running"); len(found) != 0 {
+			t.Fatal("removed word should not be found", found)
+		}
+		if found := titles(encyclopedia, "tree"); !equal(found, []string{"Apple"}) {
+			t.Fatal("added word should be found", found)
+		}
+		if found := titles(encyclopedia, "orchards"); !equal(found, []string{"Apple"}) {
+			t.Fatal("unchanged word should be found", found)
+		}
+		if found := titles(encyclopedia, `"apple tree"`); !equal(found, []string{"Apple"}) {
+			t.Fatal("positions of a changed article should be updated", found)
+		}
+		if found := titles(encyclopedia, `"orchards grow"`); !equal(found, []string{"Apple"}) {
+			t.Fatal("positions of an unchanged word should be updated", found)
+		}
+
+		// the statistics match a build of the newer dump
+		if !changes {
+			statistics, err := encyclopedia.Statistics()
+			if err != nil {
+				t.Fatal(err)
+			}
+			options := testOptions(dir)
+			options.DB = filepath.Join(dir, "fresh.db")
+			options.Dump = filepath.Join("testdata", "pages-articles-update.xml.bz2")
+			err = Build(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fresh, err := Open(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer fresh.Close()
+			expected, err := fresh.Statistics()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if statistics != expected {
+				t.Fatal("invalid statistics", statistics, expected)
+			}
+		}
+	}
+	test(false)
+	test(true)
+}
@@ -6,39 +6,81 @@ package wikipedia
 
 import (
 	"bytes"
-	"compress/bzip2"
+	"crypto/sha1"
 	"encoding/binary"
-	"encoding/xml"
 	"fmt"
 	"html/template"
 	"io"
 	"math"
+	"math/big"
 	"net/url"
-	"os"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pointlander/compress"
 	"github.com/pointlander/pagerank"
+	"github.com/pointlander/wikipedia/query"
 
 	"github.com/boltdb/bolt"
 	"github.com/golang/protobuf/proto"
 )
 
 var (
-	// WikiRegex is a regex for wiki syntax
-	WikiRegex = regexp.MustCompile("[^A-Za-z]+")
+	// CategoryRegex is a regex for the category links of a page
+	CategoryRegex = regexp.MustCompile(`(?i)\[\[\s*category\s*:\s*([^\]|]+)`)
+	// RedirectRegex is a regex for redirect pages
+	RedirectRegex = regexp.MustCompile(`(?i)^\s*#redirect\s*:?\s*\[\[([^\]|]+)`)
 	// NumCPU is the number of CPUs
 	NumCPU = runtime.NumCPU()
 )
 
 // Page is a wikitext page
 type Page struct {
-	Title string `xml:"title"`
-	ID    uint64 `xml:"id"`
-	Text  string `xml:"revision>text"`
+	Title     string `xml:"title"`
+	Namespace int32  `xml:"ns"`
+	ID        uint64 `xml:"id"`
+	Text      string `xml:"revision>text"`
+	// Revision is the metadata of the revision of the page
+	Revision  uint64 `xml:"revision>id"`
+	Parent    uint64 `xml:"revision>parentid"`
+	Timestamp string `xml:"revision>timestamp"`
+	Username  string `xml:"revision>contributor>username"`
+	UserID    uint64 `xml:"revision>contributor>id"`
+	IP        string `xml:"revision>contributor>ip"`
+	Comment   string `xml:"revision>comment"`
+	Model     string `xml:"revision>model"`
+	Format    string `xml:"revision>format"`
+	SHA1      string `xml:"revision>sha1"`
+	// Redirect is the target of a redirect page
+	Redirect struct {
+		Title string `xml:"title,attr"`
+	} `xml:"redirect"`
+	// Offset is the offset of the stream holding the page in a multistream dump
+	Offset int64 `xml:"-"`
+}
+
+// MaxRedirects is the maximum number of redirects followed by a lookup
+const MaxRedirects = 8
+
+// Target returns the target of a redirect page including the section anchor,
+// or an empty string if the page isn't a redirect
+func (p *Page) Target() string {
+	if matches := RedirectRegex.FindStringSubmatch(p.Text); matches != nil {
+		return strings.TrimSpace(matches[1])
+	}
+	return p.Redirect.Title
+}
+
+// SplitAnchor splits a link into its title and section anchor
+func SplitAnchor(link string) (title, anchor string) {
+	if i := strings.IndexByte(link, '#'); i >= 0 {
+		return strings.TrimSpace(link[:i]), strings.TrimSpace(link[i+1:])
+	}
+	return strings.TrimSpace(link), ""
 }
 
 // Result is a search result
@@ -48,6 +90,10 @@ type Result struct {
 	Rank    float32
 	Article *Article
 	Matches int
+	// Score is the BM25 score of the article blended with its rank
+	Score float64
+	// Snippets are the passages of the article that best match the query
+	Snippets []Snippet
 }
 
 // Compress compresses some data
@@ -67,124 +113,699 @@ func Decompress(input io.Reader, output []byte) {
 	compress.BijectiveBurrowsWheelerDecoder(channel).MoveToFrontDecoder().FilteredAdaptiveBitDecoder().Decode(input)
 }
 
+const (
+	// DefaultDump is the default wikipedia dump
+	DefaultDump = "enwiki-latest-pages-articles.xml.bz2"
+	// DefaultDB is the default database
+	DefaultDB = "wikipedia.db"
+	// DefaultMemory is the default memory ceiling in gigabytes
+	DefaultMemory = 127
+	// DefaultCheckpoint is the default number of pages between checkpoints
+	DefaultCheckpoint = 1 << 16
+	// DefaultK1 is the default term frequency saturation of BM25
+	DefaultK1 = 1.2
+	// DefaultB is the default length normalization of BM25
+	DefaultB = 0.75
+	// DefaultWeight is the default weight of the page rank in the score
+	DefaultWeight = 1
+	// DefaultLimit is the default number of results of a page of a search
+	DefaultLimit = 20
+	// DefaultCandidates is the default number of matching articles that are
+	// scored by a search
+	DefaultCandidates = 1 << 16
+)
+
+// Options are the options for opening, building and ranking an encyclopedia
+type Options struct {
+	// Dump is the path to the wikipedia dump
+	Dump string
+	// Index is the path to the index of a multistream dump, the dump is
+	// decoded as a multistream dump when it is set
+	Index string
+	// Changes is true if the dump of an update only holds the added and
+	// changed pages, so pages missing from it are not deleted
+	Changes bool
+	// DB is the path to the database
+	DB string
+	// Bolt are the options for opening the database
+	Bolt *bolt.Options
+	// Memory is the memory ceiling in gigabytes
+	Memory float64
+	// Checkpoint is the number of pages between checkpoints of a build
+	Checkpoint int
+	// Include are the namespaces included by a build, all of the namespaces
+	// are included if it is empty
+	Include []int32
+	// Exclude are the namespaces excluded by a build
+	Exclude []int32
+	// Analysis selects the analyzer of the text of articles and queries, the
+	// analysis recorded in the db takes precedence
+	Analysis *Analysis
+	// K1 is the term frequency saturation of BM25, DefaultK1 if nil
+	K1 *float64
+	// B is the length normalization of BM25, DefaultB if nil
+	B *float64
+	// Weight is the weight of the page rank in the score of a search result,
+	// DefaultWeight if nil
+	Weight *float64
+	// Regex enables the regular expression terms of search queries, /pattern/,
+	// they are words otherwise
+	Regex bool
+}
+
+// Included returns true if the namespace is included by a build
+func (o Options) Included(namespace int32) bool {
+	for _, exclude := range o.Exclude {
+		if exclude == namespace {
+			return false
+		}
+	}
+	if len(o.Include) == 0 {
+		return true
+	}
+	for _, include := range o.Include {
+		if include == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// defaults fills in the zero valued and nil options with their defaults
+func (o Options) defaults() Options {
+	if o.Dump == "" {
+		o.Dump = DefaultDump
+	}
+	if o.DB == "" {
+		o.DB = DefaultDB
+	}
+	if o.Memory == 0 {
+		o.Memory = DefaultMemory
+	}
+	if o.Checkpoint == 0 {
+		o.Checkpoint = DefaultCheckpoint
+	}
+	if o.Analysis == nil {
+		o.Analysis = NewAnalysis(DefaultLanguage)
+	}
+	if o.K1 == nil {
+		k1 := float64(DefaultK1)
+		o.K1 = &k1
+	}
+	if o.B == nil {
+		b := float64(DefaultB)
+		o.B = &b
+	}
+	if o.Weight == nil {
+		weight := float64(DefaultWeight)
+		o.Weight = &weight
+	}
+	return o
+}
+
 // Encyclopedia is an encyclopedia
 type Encyclopedia struct {
-	DB              *bolt.DB
-	entryTemplate   *template.Template
-	resultsTemplate *template.Template
+	DB                     *bolt.DB
+	Options                Options
+	Analyzer               *Analyzer
+	entryTemplate          *template.Template
+	resultsTemplate        *template.Template
+	disambiguationTemplate *template.Template
 }
 
 // Open opens an encyclopedia
-func Open(readonly bool) (*Encyclopedia, error) {
-	db, err := bolt.Open("wikipedia.db", 0600, &bolt.Options{ReadOnly: readonly})
+func Open(options Options) (*Encyclopedia, error) {
+	options = options.defaults()
+	db, err := bolt.Open(options.DB, 0600, options.Bolt)
 	if err != nil {
 		return nil, err
 	}
+	err = db.View(func(tx *bolt.Tx) error {
+		analysis, err := getAnalysis(tx)
+		if analysis != nil {
+			options.Analysis = analysis
+		}
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	analyzer, err := NewAnalyzer(options.Analysis)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
 	return &Encyclopedia{
-		DB: db,
+		DB:       db,
+		Options:  options,
+		Analyzer: analyzer,
 	}, nil
 }
 
-// Build builds the db
-func Build() {
-	encyclopedia, err := Open(false)
+// Close closes the encyclopedia
+func (e *Encyclopedia) Close() error {
+	return e.DB.Close()
+}
+
+// Entry is a page that has been prepared for the db
+type Entry struct {
+	Page      uint64
+	Offset    int64
+	Title     string
+	Namespace int32
+	Redirect  string
+	Value     []byte
+	Terms     map[string][]uint32
+	Length    uint32
+	// Words are the words of the spelling dictionary in the text
+	Words []string
+	Err   error
+}
+
+// Terms returns the positions of the terms of an article and the length of
+// its text in terms. The terms of the title and the categories of the
+// article are prefixed with title: and category:.
+func Terms(analyzer *Analyzer, title, text string) (map[string][]uint32, uint32) {
+	terms, length := analyzer.Terms(text), 0
+	for _, positions := range terms {
+		length += len(positions)
+	}
+	// field adds the terms of a field starting at the position and returns
+	// the position after the last term
+	field := func(name string, position uint32, text string) uint32 {
+		next := position
+		for _, token := range analyzer.Analyze(text) {
+			term := name + ":" + token.Term
+			terms[term] = append(terms[term], position+token.Position)
+			next = position + token.Position + 1
+		}
+		return next
+	}
+	field("title", 0, title)
+	// the categories are a word apart so that phrases don't span them
+	position := uint32(0)
+	for _, category := range CategoryRegex.FindAllStringSubmatch(text, -1) {
+		position = field("category", position, category[1]) + 1
+	}
+	return terms, uint32(length)
+}
+
+// encodeArticle marshals and compresses an article for the pages bucket
+func encodeArticle(article *Article) ([]byte, error) {
+	encoded, err := proto.Marshal(article)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	pressed := bytes.Buffer{}
+	compress.Mark1Compress16(encoded, &pressed)
+	compressed := Compressed{
+		Size: uint64(len(encoded)),
+		Data: pressed.Bytes(),
 	}
-	db := encyclopedia.DB
+	return proto.Marshal(&compressed)
+}
 
-	input, err := os.Open("enwiki-latest-pages-articles.xml.bz2")
+// prepare compresses the page and collects the positions of its terms
+func prepare(analyzer *Analyzer, page Page) Entry {
+	if target := page.Target(); target != "" {
+		return Entry{
+			Page:     page.ID,
+			Offset:   page.Offset,
+			Title:    page.Title,
+			Redirect: target,
+		}
+	}
+	article := Article{
+		Title:     page.Title,
+		ID:        page.ID,
+		Text:      page.Text,
+		Namespace: page.Namespace,
+		Revision:  page.Revision,
+		Parent:    page.Parent,
+		Timestamp: page.Timestamp,
+		Username:  page.Username,
+		UserID:    page.UserID,
+		IP:        page.IP,
+		Comment:   page.Comment,
+		Model:     page.Model,
+		Format:    page.Format,
+		SHA1:      page.SHA1,
+	}
+	value, err := encodeArticle(&article)
 	if err != nil {
-		panic(err)
+		return Entry{Err: err}
 	}
-	defer input.Close()
-	reader := bzip2.NewReader(input)
-	decoder := xml.NewDecoder(reader)
-	lru := NewLRU(20)
-	type Result struct {
-		Title string
-		Value []byte
-		Words map[string]bool
+	terms, length := Terms(analyzer, page.Title, page.Text)
+	return Entry{
+		Page:      page.ID,
+		Offset:    page.Offset,
+		Title:     page.Title,
+		Namespace: page.Namespace,
+		Value:     value,
+		Terms:     terms,
+		Length:    length,
+		Words:     analyzer.Vocabulary(page.Text),
 	}
-	flush := func(node *Node) error {
-		err := db.Update(func(tx *bolt.Tx) error {
-			idx, err := tx.CreateBucketIfNotExists([]byte("index"))
-			if err != nil {
-				return err
-			}
+}
 
-			for node != nil {
-				indexes := Index{
-					Indexes: node.Index,
-				}
-				value, err := proto.Marshal(&indexes)
-				if err != nil {
-					panic(err)
-				}
-				pressed := bytes.Buffer{}
-				Compress(value, &pressed)
-				compressed := Compressed{
-					Size: uint64(len(value)),
-					Data: pressed.Bytes(),
-				}
-				v, err := proto.Marshal(&compressed)
-				if err != nil {
-					panic(err)
-				}
-				key := []byte(node.Key)
-				if len(key) > bolt.MaxKeySize {
-					key = key[:bolt.MaxKeySize]
-				}
-				err = idx.Put(key, v)
-				if err != nil {
-					return err
-				}
-				node = node.B
-			}
+// ParseNamespaces parses a comma separated list of namespaces, a namespace
+// is an id or a name of the namespace table. The names are compared as
+// normalized titles, so talk and Talk are the same.
+func ParseNamespaces(list string, table map[int32]string) ([]int32, error) {
+	names := make(map[string]int32, len(table))
+	for key, name := range table {
+		if name != "" {
+			names[NormalizeTitle(name)] = key
+		}
+	}
+	namespaces := make([]int32, 0, 8)
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if key, has := names[NormalizeTitle(part)]; has {
+			namespaces = append(namespaces, key)
+			continue
+		}
+		namespace, err := strconv.ParseInt(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unknown namespace %s", part)
+		}
+		namespaces = append(namespaces, int32(namespace))
+	}
+	return namespaces, nil
+}
+
+// ParseNamespaces parses a comma separated list of namespaces with the
+// namespace table of the dump
+func (e *Encyclopedia) ParseNamespaces(list string) ([]int32, error) {
+	table, err := e.Namespaces()
+	if err != nil {
+		return nil, err
+	}
+	return ParseNamespaces(list, table)
+}
+
+// putNamespaces puts the namespace table of the siteinfo into the namespaces bucket
+func putNamespaces(tx *bolt.Tx, siteinfo *Siteinfo) error {
+	namespaces, err := tx.CreateBucketIfNotExists([]byte("namespaces"))
+	if err != nil {
+		return err
+	}
+	for _, namespace := range siteinfo.Namespaces {
+		key := make([]byte, 4)
+		binary.LittleEndian.PutUint32(key, uint32(namespace.Key))
+		err := namespaces.Put(key, []byte(namespace.Name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Namespaces returns the namespace table of the dump
+func (e *Encyclopedia) Namespaces() (map[int32]string, error) {
+	namespaces := make(map[int32]string)
+	err := e.DB.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte("namespaces"))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(key, value []byte) error {
+			namespaces[int32(binary.LittleEndian.Uint32(key))] = string(value)
 			return nil
 		})
+	})
+	return namespaces, err
+}
+
+// getDocument gets the metadata of an article from the documents bucket
+func getDocument(documents *bolt.Bucket, key []byte) (*Document, error) {
+	document := Document{}
+	if documents == nil {
+		return &document, nil
+	}
+	value := documents.Get(key)
+	if value == nil {
+		return &document, nil
+	}
+	err := proto.Unmarshal(value, &document)
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// putDocument puts the metadata of an article into the documents bucket
+func putDocument(documents *bolt.Bucket, key []byte, document *Document) error {
+	value, err := proto.Marshal(document)
+	if err != nil {
 		return err
 	}
+	return documents.Put(key, value)
+}
+
+// getArticle gets an article from the pages bucket
+func getArticle(pages *bolt.Bucket, key []byte) (*Article, error) {
+	value := pages.Get(key)
+	if value == nil {
+		return nil, nil
+	}
+	compressed := Compressed{}
+	err := proto.Unmarshal(value, &compressed)
+	if err != nil {
+		return nil, err
+	}
+	pressed, output := bytes.NewReader(compressed.Data), make([]byte, compressed.Size)
+	compress.Mark1Decompress16(pressed, output)
+	article := Article{}
+	err = proto.Unmarshal(output, &article)
+	if err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+// indexKey is the key of a word in the index bucket
+func indexKey(word string) []byte {
+	key := []byte(word)
+	if len(key) > bolt.MaxKeySize {
+		key = key[:bolt.MaxKeySize]
+	}
+	return key
+}
+
+// Posting is an article of a posting list and the positions of the word in
+// the article
+type Posting struct {
+	Index     uint32
+	Positions []uint32
+}
 
-	results := make(chan Result, 8)
-	process := func(page Page) {
-		article := Article{
-			Title: page.Title,
-			ID:    page.ID,
-			Text:  page.Text,
+// getIndex gets the posting list of a word from the index bucket
+func getIndex(idx *bolt.Bucket, word string) (*Index, error) {
+	value := idx.Get(indexKey(word))
+	if len(value) == 0 {
+		return &Index{}, nil
+	}
+	compressed := Compressed{}
+	err := proto.Unmarshal(value, &compressed)
+	if err != nil {
+		return nil, err
+	}
+	pressed, output := bytes.NewReader(compressed.Data), make([]byte, compressed.Size)
+	Decompress(pressed, output)
+	index := Index{}
+	err = proto.Unmarshal(output, &index)
+	if err != nil {
+		return nil, err
+	}
+	return &index, nil
+}
+
+// putIndex puts the posting list of a word into the index bucket
+func putIndex(idx *bolt.Bucket, word string, index *Index) error {
+	value, err := proto.Marshal(index)
+	if err != nil {
+		return err
+	}
+	pressed := bytes.Buffer{}
+	Compress(value, &pressed)
+	compressed := Compressed{
+		Size: uint64(len(value)),
+		Data: pressed.Bytes(),
+	}
+	v, err := proto.Marshal(&compressed)
+	if err != nil {
+		return err
+	}
+	return idx.Put(indexKey(word), v)
+}
+
+// getBacklinks gets the indexes of the articles that link to an article from
+// the backlinks bucket
+func getBacklinks(backlinks *bolt.Bucket, key []byte) (*Sources, error) {
+	links := Sources{}
+	if backlinks == nil {
+		return &links, nil
+	}
+	value := backlinks.Get(key)
+	if len(value) == 0 {
+		return &links, nil
+	}
+	compressed := Compressed{}
+	err := proto.Unmarshal(value, &compressed)
+	if err != nil {
+		return nil, err
+	}
+	pressed, output := bytes.NewReader(compressed.Data), make([]byte, compressed.Size)
+	Decompress(pressed, output)
+	err = proto.Unmarshal(output, &links)
+	if err != nil {
+		return nil, err
+	}
+	return &links, nil
+}
+
+// putBacklinks puts the indexes of the articles that link to an article into
+// the backlinks bucket
+func putBacklinks(backlinks *bolt.Bucket, key []byte, links *Sources) error {
+	value, err := proto.Marshal(links)
+	if err != nil {
+		return err
+	}
+	pressed := bytes.Buffer{}
+	Compress(value, &pressed)
+	compressed := Compressed{
+		Size: uint64(len(value)),
+		Data: pressed.Bytes(),
+	}
+	v, err := proto.Marshal(&compressed)
+	if err != nil {
+		return err
+	}
+	return backlinks.Put(key, v)
+}
+
+// appendPosting appends a posting for an article with a larger index than
+// the articles of the posting list
+func appendPosting(index *Index, posting Posting) {
+	if tail := len(index.Indexes) - 1; tail >= 0 {
+		index.Indexes[tail] = posting.Index - index.Indexes[tail]
+	}
+	index.Indexes = append(index.Indexes, posting.Index)
+	index.Counts = append(index.Counts, uint32(len(posting.Positions)))
+	last := uint32(0)
+	for _, position := range posting.Positions {
+		index.Positions = append(index.Positions, position-last)
+		last = position
+	}
+}
+
+// DecodeIndex decodes a posting list into postings with ascending article
+// indexes, every entry of the indexes is the difference to the next entry
+// and the last entry is the last article index
+func DecodeIndex(index *Index) []Posting {
+	indexes := index.Indexes
+	postings := make([]Posting, len(indexes))
+	if len(indexes) == 0 {
+		return postings
+	}
+	last := len(indexes) - 1
+	postings[last].Index = indexes[last]
+	for i := last - 1; i >= 0; i-- {
+		postings[i].Index = postings[i+1].Index - indexes[i]
+	}
+	if len(index.Counts) != len(indexes) {
+		return postings
+	}
+	offset := 0
+	for i, count := range index.Counts {
+		positions, position := make([]uint32, count), uint32(0)
+		for j := range positions {
+			position += index.Positions[offset+j]
+			positions[j] = position
 		}
-		encoded, err := proto.Marshal(&article)
-		if err != nil {
-			panic(err)
+		postings[i].Positions = positions
+		offset += int(count)
+	}
+	return postings
+}
+
+// EncodeIndex encodes postings with ascending article indexes into a posting list
+func EncodeIndex(postings []Posting) *Index {
+	index := Index{
+		Indexes: make([]uint32, 0, len(postings)),
+		Counts:  make([]uint32, 0, len(postings)),
+	}
+	for _, posting := range postings {
+		appendPosting(&index, posting)
+	}
+	return &index
+}
+
+// Checkpoint is the progress of a build
+type Checkpoint struct {
+	// Dump is the name of the dump being built
+	Dump string
+	// Page is the ID of the last committed page
+	Page uint64
+	// Offset is the offset of the stream holding the last committed page
+	Offset int64
+	// Done is true if the build is complete
+	Done bool
+}
+
+// Checkpoint returns the progress of the build from the meta bucket
+func (e *Encyclopedia) Checkpoint() (checkpoint Checkpoint, err error) {
+	err = e.DB.View(func(tx *bolt.Tx) error {
+		meta := tx.Bucket([]byte("meta"))
+		if meta == nil {
+			return nil
 		}
-		pressed := bytes.Buffer{}
-		compress.Mark1Compress16(encoded, &pressed)
-		compressed := Compressed{
-			Size: uint64(len(encoded)),
-			Data: pressed.Bytes(),
+		checkpoint.Dump = string(meta.Get([]byte("dump")))
+		if value := meta.Get([]byte("page")); len(value) == 8 {
+			checkpoint.Page = binary.LittleEndian.Uint64(value)
 		}
-		value, err := proto.Marshal(&compressed)
-		if err != nil {
-			panic(err)
-		}
-		text := WikiRegex.ReplaceAllLiteralString(page.Text, " ")
-		parts := strings.Split(text, " ")
-		words := make(map[string]bool)
-		for _, part := range parts {
-			part = strings.ToLower(strings.TrimSpace(part))
-			if len(part) == 0 {
-				continue
-			}
-			words[part] = true
+		if value := meta.Get([]byte("offset")); len(value) == 8 {
+			checkpoint.Offset = int64(binary.LittleEndian.Uint64(value))
 		}
-		results <- Result{
-			Title: page.Title,
-			Value: value,
-			Words: words,
+		checkpoint.Done = meta.Get([]byte("done")) != nil
+		return nil
+	})
+	return checkpoint, err
+}
+
+// save saves the checkpoint to the meta bucket
+func (c *Checkpoint) save(meta *bolt.Bucket) error {
+	err := meta.Put([]byte("dump"), []byte(c.Dump))
+	if err != nil {
+		return err
+	}
+	value := make([]byte, 8)
+	binary.LittleEndian.PutUint64(value, c.Page)
+	err = meta.Put([]byte("page"), value)
+	if err != nil {
+		return err
+	}
+	value = make([]byte, 8)
+	binary.LittleEndian.PutUint64(value, uint64(c.Offset))
+	err = meta.Put([]byte("offset"), value)
+	if err != nil {
+		return err
+	}
+	if c.Done {
+		return meta.Put([]byte("done"), []byte{1})
+	}
+	return nil
+}
+
+// Statistics are the collection statistics of the articles used for scoring
+type Statistics struct {
+	// Documents is the number of articles
+	Documents uint64
+	// Length is the sum of the lengths of the articles in terms
+	Length uint64
+}
+
+// getStatistics gets the collection statistics from the meta bucket
+func getStatistics(meta *bolt.Bucket) (statistics Statistics) {
+	if meta == nil {
+		return statistics
+	}
+	if value := meta.Get([]byte("documents")); len(value) == 8 {
+		statistics.Documents = binary.LittleEndian.Uint64(value)
+	}
+	if value := meta.Get([]byte("length")); len(value) == 8 {
+		statistics.Length = binary.LittleEndian.Uint64(value)
+	}
+	return statistics
+}
+
+// Statistics returns the collection statistics from the meta bucket
+func (e *Encyclopedia) Statistics() (statistics Statistics, err error) {
+	err = e.DB.View(func(tx *bolt.Tx) error {
+		statistics = getStatistics(tx.Bucket([]byte("meta")))
+		return nil
+	})
+	return statistics, err
+}
+
+// save saves the collection statistics to the meta bucket
+func (s *Statistics) save(meta *bolt.Bucket) error {
+	value := make([]byte, 8)
+	binary.LittleEndian.PutUint64(value, s.Documents)
+	err := meta.Put([]byte("documents"), value)
+	if err != nil {
+		return err
+	}
+	value = make([]byte, 8)
+	binary.LittleEndian.PutUint64(value, s.Length)
+	return meta.Put([]byte("length"), value)
+}
+
+// Build builds the db, the progress of the build is checkpointed so that an
+// interrupted build continues where it stopped
+func Build(options Options) error {
+	encyclopedia, err := Open(options)
+	if err != nil {
+		return err
+	}
+	defer encyclopedia.Close()
+	db, options := encyclopedia.DB, encyclopedia.Options
+
+	checkpoint, err := encyclopedia.Checkpoint()
+	if err != nil {
+		return err
+	}
+	statistics, err := encyclopedia.Statistics()
+	if err != nil {
+		return err
+	}
+	dump := filepath.Base(options.Dump)
+	if checkpoint.Dump != "" && checkpoint.Dump != dump {
+		return fmt.Errorf("database is built from %s not %s", checkpoint.Dump, dump)
+	}
+	if checkpoint.Done {
+		return nil
+	}
+	checkpoint.Dump = dump
+
+	siteinfo, err := ReadSiteinfo(options.Dump)
+	if err != nil {
+		return err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		err := putAnalysis(tx, options.Analysis)
+		if err != nil {
+			return err
 		}
+		return putNamespaces(tx, siteinfo)
+	})
+	if err != nil {
+		return err
 	}
 
-	write := func(wiki, pages, idx *bolt.Bucket, result Result) error {
+	input, stop, decoded := make(chan Page, 8), make(chan struct{}), make(chan error, 1)
+	defer close(stop)
+	go func() {
+		decoded <- Pages(options, checkpoint, input, stop)
+	}()
+	lru := NewLRU(20)
+	write := func(wiki, titles, pages, documents, idx, grams, redirects *bolt.Bucket, result Entry) error {
+		if result.Err != nil {
+			return result.Err
+		}
+		checkpoint.Page, checkpoint.Offset = result.Page, result.Offset
+		if result.Redirect != "" {
+			err := titles.Put(titleKey(result.Title), []byte{})
+			if err != nil {
+				return err
+			}
+			return redirects.Put([]byte(result.Title), []byte(result.Redirect))
+		}
 		index, err := wiki.NextSequence()
 		if err != nil {
 			return err
@@ -199,196 +820,220 @@ func Build() {
 		if err != nil {
 			return err
 		}
+		err = titles.Put(titleKey(result.Title), value)
+		if err != nil {
+			return err
+		}
 		err = pages.Put(value, result.Value)
 		if err != nil {
 			return err
 		}
-		for part := range result.Words {
-			node, has := lru.Get(part)
-			if !has {
-				compressed := Compressed{}
-				value := idx.Get([]byte(part))
-				if len(value) > 0 {
-					err = proto.Unmarshal(value, &compressed)
-					if err != nil {
-						return err
-					}
-					pressed, output := bytes.NewReader(compressed.Data), make([]byte, compressed.Size)
-					Decompress(pressed, output)
-					indexes := Index{}
-					err = proto.Unmarshal(output, &indexes)
-					if err != nil {
-						return err
-					}
-					node.Index = indexes.Indexes
-				}
-			}
-			tail := len(node.Index) - 1
-			if tail >= 0 {
-				node.Index[tail] = uint32(index) - node.Index[tail]
+		err = putDocument(documents, value, &Document{
+			Namespace: result.Namespace,
+			Length:    result.Length,
+		})
+		if err != nil {
+			return err
+		}
+		statistics.Documents++
+		statistics.Length += uint64(result.Length)
+		for _, word := range result.Words {
+			err := putWord(grams, word)
+			if err != nil {
+				return err
 			}
-			node.Index = append(node.Index, uint32(index))
 		}
-		return nil
-	}
-
-	flight := 0
-	err = db.Update(func(tx *bolt.Tx) error {
-		token, err := decoder.Token()
-		for err == nil && flight < NumCPU {
-			switch element := token.(type) {
-			case xml.StartElement:
-				if element.Name.Local == "page" {
-					var page Page
-					decoder.DecodeElement(&page, &element)
-					if len(page.Text) == 0 {
-						break
-					}
-					go process(page)
-					flight++
-					var m runtime.MemStats
-					runtime.ReadMemStats(&m)
-					alloc := float64(m.Alloc) / float64(1024*1024*1024)
-					if alloc > 127 {
-						return nil
-					}
+		for term, positions := range result.Terms {
+			node, has := lru.Get(term)
+			if !has {
+				node.Index, err = getIndex(idx, term)
+				if err != nil {
+					return err
 				}
 			}
-			token, err = decoder.Token()
+			appendPosting(node.Index, Posting{
+				Index:     uint32(index),
+				Positions: positions,
+			})
+			node.Dirty = true
 		}
 		return nil
-	})
-	if err != nil {
-		panic(err)
 	}
 
-	done := false
+	// the results are written in the order of the dump, so everything up to
+	// the checkpoint is committed together with the posting lists and the
+	// checkpoint itself
+	pending, done := make([]chan Entry, 0, NumCPU), false
 	for !done {
-		var node *Node
 		err = db.Update(func(tx *bolt.Tx) error {
 			wiki, err := tx.CreateBucketIfNotExists([]byte("wiki"))
 			if err != nil {
 				return err
 			}
+			titles, err := tx.CreateBucketIfNotExists([]byte("titles"))
+			if err != nil {
+				return err
+			}
 			pages, err := tx.CreateBucketIfNotExists([]byte("pages"))
 			if err != nil {
 				return err
 			}
+			documents, err := tx.CreateBucketIfNotExists([]byte("documents"))
+			if err != nil {
+				return err
+			}
 			idx, err := tx.CreateBucketIfNotExists([]byte("index"))
 			if err != nil {
 				return err
 			}
+			grams, err := tx.CreateBucketIfNotExists([]byte("trigrams"))
+			if err != nil {
+				return err
+			}
+			redirects, err := tx.CreateBucketIfNotExists([]byte("redirects"))
+			if err != nil {
+				return err
+			}
+			templates, err := tx.CreateBucketIfNotExists([]byte("templates"))
+			if err != nil {
+				return err
+			}
+			meta, err := tx.CreateBucketIfNotExists([]byte("meta"))
+			if err != nil {
+				return err
+			}
+			next := func() error {
+				result := <-pending[0]
+				pending = pending[1:]
+				return write(wiki, titles, pages, documents, idx, grams, redirects, result)
+			}
 
-			token, err := decoder.Token()
-			for err == nil {
-				switch element := token.(type) {
-				case xml.StartElement:
-					if element.Name.Local == "page" {
-						if flight > 0 {
-							result := <-results
-							err := write(wiki, pages, idx, result)
-							if err != nil {
-								return err
-							}
-							flight--
-						}
-
-						node = lru.Flush()
-						if node != nil {
-							return nil
+			written, full := 0, false
+			for !full {
+				page, ok := <-input
+				if !ok {
+					for len(pending) > 0 {
+						err := next()
+						if err != nil {
+							return err
 						}
+					}
+					err := <-decoded
+					if err != nil {
+						return err
+					}
+					done = true
+					break
+				}
+				// the templates are kept for expansion even if their
+				// namespace isn't included
+				if page.Namespace == TemplateNamespace && len(page.Text) > 0 {
+					err := putTemplate(templates, page)
+					if err != nil {
+						return err
+					}
+				}
+				if len(page.Text) == 0 || !options.Included(page.Namespace) {
+					continue
+				}
+				if len(pending) >= NumCPU {
+					err := next()
+					if err != nil {
+						return err
+					}
+					written++
+				}
+				results := make(chan Entry, 1)
+				go func(page Page) {
+					results <- prepare(encyclopedia.Analyzer, page)
+				}(page)
+				pending = append(pending, results)
 
-						var page Page
-						decoder.DecodeElement(&page, &element)
-						if len(page.Text) == 0 {
-							break
-						}
-						go process(page)
-						flight++
-
-						var m runtime.MemStats
-						runtime.ReadMemStats(&m)
-						alloc := float64(m.Alloc) / float64(1024*1024*1024)
-						if alloc > 127 {
-							return nil
-						}
+				for node := lru.Flush(); node != nil; node = node.B {
+					if !node.Dirty {
+						continue
+					}
+					err := putIndex(idx, node.Key, node.Index)
+					if err != nil {
+						return err
 					}
 				}
-				token, err = decoder.Token()
-			}
-			done = true
-			return nil
-		})
-		if err != nil {
-			panic(err)
-		}
 
-		if node != nil {
-			err := flush(node)
-			if err != nil {
-				panic(err)
+				var m runtime.MemStats
+				runtime.ReadMemStats(&m)
+				alloc := float64(m.Alloc) / float64(1024*1024*1024)
+				full = alloc > options.Memory || written >= options.Checkpoint
 			}
-		}
-	}
-
-	err = db.Update(func(tx *bolt.Tx) error {
-		wiki, err := tx.CreateBucketIfNotExists([]byte("wiki"))
-		if err != nil {
-			return err
-		}
-		pages, err := tx.CreateBucketIfNotExists([]byte("pages"))
-		if err != nil {
-			return err
-		}
-		idx, err := tx.CreateBucketIfNotExists([]byte("index"))
-		if err != nil {
-			return err
-		}
 
-		for i := 0; i < flight; i++ {
-			result := <-results
-			err := write(wiki, pages, idx, result)
+			for _, node := range lru.Nodes {
+				if !node.Dirty {
+					continue
+				}
+				err := putIndex(idx, node.Key, node.Index)
+				if err != nil {
+					return err
+				}
+				node.Dirty = false
+			}
+			checkpoint.Done = done
+			err = statistics.save(meta)
 			if err != nil {
 				return err
 			}
-
-			var m runtime.MemStats
-			runtime.ReadMemStats(&m)
-			alloc := float64(m.Alloc) / float64(1024*1024*1024)
-			if alloc > 127 {
-				return nil
-			}
+			return checkpoint.save(meta)
+		})
+		if err != nil {
+			return err
 		}
-		return nil
-	})
-	if err != nil {
-		panic(err)
-	}
-
-	err = flush(lru.Head)
-	if err != nil {
-		panic(err)
 	}
+	return nil
 }
 
-// Rank ranks the pages
-func Rank() {
-	graph := pagerank.NewGraph32(1024)
+// Rank ranks the pages, the indexes of the articles that link to each article
+// are put into the backlinks bucket
+func Rank(options Options) error {
+	graph, backlinks := pagerank.NewGraph32(1024), make(map[uint32][]uint32)
 	graph.Verbose = true
-	encyclopedia, err := Open(false)
+	encyclopedia, err := Open(options)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	db := encyclopedia.DB
+	defer encyclopedia.Close()
+	db, options := encyclopedia.DB, encyclopedia.Options
 	err = db.View(func(tx *bolt.Tx) error {
 		wiki := tx.Bucket([]byte("wiki"))
 		pages := tx.Bucket([]byte("pages"))
+		redirects := tx.Bucket([]byte("redirects"))
 		cursor := pages.Cursor()
 		key, value := cursor.First()
 		i, flight := 0, 0
 		type Result struct {
 			Source uint32
-			Links  []uint32
+			Links  []string
+			Err    error
+		}
+		link := func(source uint32, links []string) {
+			for _, link := range links {
+				title, _ := SplitAnchor(link)
+				for j := 0; j <= MaxRedirects; j++ {
+					if value := wiki.Get([]byte(title)); len(value) > 0 {
+						target := binary.LittleEndian.Uint32(value)
+						graph.Link(uint64(source), uint64(target), 1.0)
+						if target != source {
+							backlinks[target] = append(backlinks[target], source)
+						}
+						break
+					}
+					if redirects == nil {
+						break
+					}
+					target := redirects.Get([]byte(title))
+					if target == nil {
+						break
+					}
+					title, _ = SplitAnchor(string(target))
+				}
+			}
 		}
 		done := make(chan Result, 8)
 		process := func(key uint32, compressed *Compressed) {
@@ -397,42 +1042,30 @@ func Rank() {
 			article := Article{}
 			err := proto.Unmarshal(output, &article)
 			if err != nil {
-				panic(err)
+				done <- Result{Err: err}
+				return
 			}
 			parser := &Wikipedia{Buffer: article.Text}
 			parser.Init()
 			if err := parser.Parse(); err != nil {
-				panic(err)
+				done <- Result{Err: err}
+				return
 			}
-			element := func(node *node32) string {
-				node = node.up
-				for node != nil {
+			links := make([]string, 0, 8)
+			// walk collects the links of the elements, lists and tables
+			var walk func(node *node32)
+			walk = func(node *node32) {
+				for ; node != nil; node = node.next {
 					switch node.pegRule {
 					case rulefree:
-						node := node.up
-						return string(parser.buffer[node.begin:node.end])
-					}
-					node = node.next
-				}
-				return ""
-			}
-			ast, links := parser.AST(), make([]uint32, 0, 8)
-			node := ast.up
-			for node != nil {
-				switch node.pegRule {
-				case ruleelement:
-					link := element(node)
-					if link != "" {
-						link = strings.TrimSpace(link)
-						value := wiki.Get([]byte(link))
-						if len(value) > 0 {
-							target := binary.LittleEndian.Uint32(value)
-							links = append(links, target)
-						}
+						link := node.up
+						links = append(links, string(parser.buffer[link.begin:link.end]))
+					default:
+						walk(node.up)
 					}
 				}
-				node = node.next
 			}
+			walk(parser.AST())
 			done <- Result{
 				Source: key,
 				Links:  links,
@@ -458,9 +1091,13 @@ func Rank() {
 		for key != nil && value != nil {
 			result := <-done
 			flight--
-			for _, link := range result.Links {
-				graph.Link(uint64(result.Source), uint64(link), 1.0)
+			if result.Err != nil {
+				for j := 0; j < flight; j++ {
+					<-done
+				}
+				return result.Err
 			}
+			link(result.Source, result.Links)
 
 			compressed := &Compressed{}
 			err = proto.Unmarshal(value, compressed)
@@ -480,14 +1117,16 @@ func Rank() {
 
 		for j := 0; j < flight; j++ {
 			result := <-done
-			for _, link := range result.Links {
-				graph.Link(uint64(result.Source), uint64(link), 1.0)
+			if result.Err != nil {
+				err = result.Err
+				continue
 			}
+			link(result.Source, result.Links)
 		}
-		return nil
+		return err
 	})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	type Rank struct {
@@ -503,15 +1142,17 @@ func Rank() {
 	})
 
 	err = db.Update(func(tx *bolt.Tx) error {
-		tx.DeleteBucket([]byte("ranks"))
-		_, err := tx.CreateBucketIfNotExists([]byte("ranks"))
-		if err != nil {
-			return err
+		for _, name := range []string{"ranks", "backlinks"} {
+			tx.DeleteBucket([]byte(name))
+			_, err := tx.CreateBucketIfNotExists([]byte(name))
+			if err != nil {
+				return err
+			}
 		}
 		return nil
 	})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	for i := 0; i < len(ranks); i += 1024 {
@@ -526,30 +1167,124 @@ func Rank() {
 				key, value := make([]byte, 4), make([]byte, 4)
 				binary.LittleEndian.PutUint32(key, uint32(rank.Node))
 				binary.LittleEndian.PutUint32(value, math.Float32bits(rank.Rank))
-				ranksBucket.Put(key, value)
+				err := ranksBucket.Put(key, value)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	targets := make([]uint32, 0, len(backlinks))
+	for target := range backlinks {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i] < targets[j]
+	})
+	for i := 0; i < len(targets); i += 1024 {
+		err := db.Update(func(tx *bolt.Tx) error {
+			backlinksBucket := tx.Bucket([]byte("backlinks"))
+			end := i + 1024
+			if end > len(targets) {
+				end = len(targets)
+			}
+			for _, target := range targets[i:end] {
+				sources := backlinks[target]
+				sort.Slice(sources, func(i, j int) bool {
+					return sources[i] < sources[j]
+				})
+				unique := sources[:0]
+				for j, source := range sources {
+					if j == 0 || source != sources[j-1] {
+						unique = append(unique, source)
+					}
+				}
+				key := make([]byte, 4)
+				binary.LittleEndian.PutUint32(key, target)
+				err := putBacklinks(backlinksBucket, key, &Sources{Indexes: unique})
+				if err != nil {
+					return err
+				}
 			}
 			return nil
 		})
 		if err != nil {
-			panic(err)
+			return err
 		}
 	}
+	return nil
+}
+
+// Anchor returns the anchor of a section title
+func Anchor(title string) string {
+	return strings.Replace(strings.TrimSpace(title), " ", "_", -1)
 }
 
 // WikiTextToHTML converts wikitext to html
-func WikiTextToHTML(input string) string {
+func WikiTextToHTML(input string) (string, error) {
+	// the ast of empty text is nil
+	if input == "" {
+		return "", nil
+	}
 	parser := &Wikipedia{Buffer: input}
 	parser.Init()
 	if err := parser.Parse(); err != nil {
-		panic(err)
+		return "", err
 	}
 	text := ""
+	// inline formats the text of a link or a heading
+	inline := func(s string) string {
+		if !strings.Contains(s, "''") && !strings.Contains(s, "<") {
+			return s
+		}
+		html, err := WikiTextToHTML(s)
+		if err != nil {
+			return s
+		}
+		return html
+	}
+	formatted, closings := quotes(parser)
+	// eol closes the tags of the apostrophes of the lines that end at or
+	// before the position
+	eol := func(position uint32) {
+		for len(closings) > 0 && closings[0].Position <= position {
+			text += closings[0].Tags
+			closings = closings[1:]
+		}
+	}
+	tags := make([]string, 0, 8)
+	format := func(node *node32) {
+		name, closes := formatTag(parser, node)
+		if name == "" {
+			return
+		} else if !closes {
+			text += "<" + name + ">"
+			tags = append(tags, name)
+			return
+		}
+		// the tags opened after the tag are closed with it, and a tag that
+		// isn't open isn't closed
+		for i := len(tags) - 1; i >= 0; i-- {
+			if tags[i] == name {
+				for j := len(tags) - 1; j >= i; j-- {
+					text += "</" + tags[j] + ">"
+				}
+				tags = tags[:i]
+				return
+			}
+		}
+	}
 	link := func(node *node32) {
 		node = node.up
 		link := string(parser.buffer[node.begin:node.end])
 		if node.next != nil && node.next.pegRule == ruletext {
 			node = node.next
-			linkText := string(parser.buffer[node.begin:node.end])
+			linkText := inline(string(parser.buffer[node.begin:node.end]))
 			text += fmt.Sprintf("<a href=\"/wiki/article/%s\">%s</a>", url.PathEscape(link), linkText)
 		} else {
 			text += fmt.Sprintf("<a href=\"/wiki/article/%s\">%s</a>", url.PathEscape(link), link)
@@ -562,6 +1297,11 @@ func WikiTextToHTML(input string) string {
 			switch node.pegRule {
 			case rulefree:
 				link(node)
+			case rulequote:
+				q := formatted[node.begin]
+				list += strings.Repeat("'", q.Literal) + q.Tags
+			case ruleformat:
+				format(node)
 			default:
 				list += string(parser.buffer[node.begin:node.end])
 			}
@@ -626,6 +1366,7 @@ func WikiTextToHTML(input string) string {
 				n = n.next
 			}
 			text += strings.TrimSpace(list)
+			eol(node.end - 1)
 			node = node.next
 		}
 		for i := len(lists) - 1; i >= 0; i-- {
@@ -633,149 +1374,659 @@ func WikiTextToHTML(input string) string {
 		}
 		lists = lists[:0]
 	}
+	heading := func(level int, node *node32) {
+		title := strings.TrimSpace(string(parser.buffer[node.up.begin:node.up.end]))
+		anchor := title
+		if plain, err := WikiTextToText(title); err == nil {
+			anchor = plain
+		}
+		text += fmt.Sprintf("<h%d id=\"%s\">%s</h%d>\n", level, template.HTMLEscapeString(Anchor(anchor)), inline(title), level)
+	}
 	cite := 0
+	var render func(node *node32)
+	// cell renders a caption or a cell of a table
+	cell := func(name string, node *node32) {
+		attributes := ""
+		for node = node.up; node != nil; node = node.next {
+			switch node.pegRule {
+			case rulecell_attributes:
+				attributes = sanitizeAttributes(string(parser.buffer[node.begin:node.end]))
+			case rulecell_content, ruleheader_content:
+				text += fmt.Sprintf("<%s%s>", name, attributes)
+				start := len(text)
+				for n := node.up; n != nil; n = n.next {
+					eol(n.begin)
+					render(n.up)
+				}
+				eol(node.end)
+				text = text[:start] + strings.TrimSpace(text[start:])
+				text += fmt.Sprintf("</%s>\n", name)
+			}
+		}
+	}
+	table := func(node *node32) {
+		open, row := false, ""
+		// cells opens the row of the cells if it isn't open
+		cells := func(node *node32, rule pegRule, name string) {
+			if !open {
+				text += fmt.Sprintf("<tr%s>\n", row)
+				open = true
+			}
+			for node = node.up; node != nil; node = node.next {
+				if node.pegRule == rule {
+					cell(name, node)
+				}
+			}
+		}
+		attributes := ""
+		if node.up != nil && node.up.pegRule == ruletable_attributes {
+			attributes = sanitizeAttributes(string(parser.buffer[node.up.begin:node.up.end]))
+		}
+		text += fmt.Sprintf("<table%s>\n", attributes)
+		for node = node.up; node != nil; node = node.next {
+			switch node.pegRule {
+			case ruletable_line:
+				for line := node.up; line != nil; line = line.next {
+					switch line.pegRule {
+					case ruletable_caption:
+						cell("caption", line)
+					case ruletable_row:
+						if open {
+							text += "</tr>\n"
+						}
+						open, row = false, ""
+						if attributes := line.up; attributes != nil {
+							row = sanitizeAttributes(string(parser.buffer[attributes.begin:attributes.end]))
+						}
+					case ruletable_headers:
+						cells(line, ruletable_header, "th")
+					case ruletable_cells:
+						cells(line, ruletable_cell, "td")
+					case ruletable_text:
+						for n := line.up; n != nil; n = n.next {
+							eol(n.begin)
+							render(n.up)
+						}
+					}
+				}
+			}
+		}
+		if open {
+			text += "</tr>\n"
+		}
+		text += "</table>\n"
+	}
+	render = func(node *node32) {
+		switch node.pegRule {
+		case ruleheading6:
+			heading(6, node)
+		case ruleheading5:
+			heading(5, node)
+		case ruleheading4:
+			heading(4, node)
+		case ruleheading3:
+			heading(3, node)
+		case ruleheading2:
+			heading(2, node)
+		case ruleheading1:
+			heading(1, node)
+		case rulehr:
+			text += fmt.Sprintf("<hr/>\n")
+		case rulebr:
+			eol(node.begin + 1)
+			text += fmt.Sprintf("<br/>\n\n")
+		case rulefree:
+			link(node)
+		case rulequote:
+			q := formatted[node.begin]
+			text += strings.Repeat("'", q.Literal) + q.Tags
+		case ruleformat:
+			format(node)
+		case rulecite:
+			text += fmt.Sprintf("<sup class=\"tooltip\">%d<span class=\"tooltiptext\">%s</span></sup>",
+				cite,
+				strings.TrimSpace(string(parser.buffer[node.begin:node.end])))
+			cite++
+		case rulelist:
+			list(node)
+		case ruletable:
+			table(node)
+		case rulewild:
+			text += string(parser.buffer[node.begin:node.end])
+		}
+	}
 	element := func(node *node32) {
+		for node = node.up; node != nil; node = node.next {
+			eol(node.begin)
+			render(node)
+		}
+	}
+	ast := parser.AST()
+	node := ast.up
+	for node != nil {
+		switch node.pegRule {
+		case ruleelement:
+			element(node)
+		}
+		node = node.next
+	}
+	eol(uint32(len(parser.buffer)))
+	for i := len(tags) - 1; i >= 0; i-- {
+		text += "</" + tags[i] + ">"
+	}
+	return text, nil
+}
+
+// WikiTextToText converts wikitext to plain text, the links are replaced by
+// their text and the citations, categories and formatting are dropped. The
+// literal apostrophes of the apostrophe formatting are kept.
+func WikiTextToText(input string) (string, error) {
+	// the ast of empty text is nil
+	if input == "" {
+		return "", nil
+	}
+	parser := &Wikipedia{Buffer: input}
+	parser.Init()
+	if err := parser.Parse(); err != nil {
+		return "", err
+	}
+	var text strings.Builder
+	// inline drops the formatting of the text of a link or a heading
+	inline := func(s string) string {
+		if !strings.Contains(s, "''") && !strings.Contains(s, "<") {
+			return s
+		}
+		plain, err := WikiTextToText(s)
+		if err != nil {
+			return s
+		}
+		return plain
+	}
+	formatted, _ := quotes(parser)
+	link := func(node *node32) {
 		node = node.up
-		for node != nil {
+		link := string(parser.buffer[node.begin:node.end])
+		if CategoryRegex.MatchString("[[" + link) {
+			return
+		}
+		if node.next != nil && node.next.pegRule == ruletext {
+			node = node.next
+			link = inline(string(parser.buffer[node.begin:node.end]))
+		}
+		text.WriteString(link)
+	}
+	list := func(node *node32) {
+		for node = node.up; node != nil; node = node.next {
+			for n := node.up; n != nil; n = n.next {
+				if n.pegRule != rulelist_content {
+					continue
+				}
+				switch n.up.pegRule {
+				case rulefree:
+					link(n.up)
+				case rulequote:
+					text.WriteString(strings.Repeat("'", formatted[n.up.begin].Literal))
+				case ruleformat:
+				default:
+					text.WriteString(string(parser.buffer[n.begin:n.end]))
+				}
+			}
+			text.WriteString("\n")
+		}
+	}
+	// cell returns the text of the content of a table cell
+	cell := func(node *node32) string {
+		for node = node.up; node != nil; node = node.next {
+			if node.pegRule == rulecell_content || node.pegRule == ruleheader_content {
+				plain, err := WikiTextToText(string(parser.buffer[node.begin:node.end]))
+				if err != nil {
+					plain = string(parser.buffer[node.begin:node.end])
+				}
+				return strings.TrimSpace(plain)
+			}
+		}
+		return ""
+	}
+	// table writes the caption and the rows of a table as lines, the cells of
+	// a row are separated by spaces
+	table := func(node *node32) {
+		for node = node.up; node != nil; node = node.next {
+			if node.pegRule != ruletable_line || node.up == nil {
+				continue
+			}
+			switch line := node.up; line.pegRule {
+			case ruletable_caption:
+				text.WriteString(cell(line))
+				text.WriteString("\n")
+			case ruletable_headers, ruletable_cells:
+				cells := make([]string, 0, 8)
+				for n := line.up; n != nil; n = n.next {
+					cells = append(cells, cell(n))
+				}
+				text.WriteString(strings.Join(cells, " "))
+				text.WriteString("\n")
+			}
+		}
+	}
+	element := func(node *node32) {
+		for node = node.up; node != nil; node = node.next {
 			switch node.pegRule {
-			case ruleheading6:
-				text += fmt.Sprintf("<h6>%s</h6>\n", strings.TrimSpace(string(parser.buffer[node.up.begin:node.up.end])))
-			case ruleheading5:
-				text += fmt.Sprintf("<h5>%s</h5>\n", strings.TrimSpace(string(parser.buffer[node.up.begin:node.up.end])))
-			case ruleheading4:
-				text += fmt.Sprintf("<h4>%s</h4>\n", strings.TrimSpace(string(parser.buffer[node.up.begin:node.up.end])))
-			case ruleheading3:
-				text += fmt.Sprintf("<h3>%s</h3>\n", strings.TrimSpace(string(parser.buffer[node.up.begin:node.up.end])))
-			case ruleheading2:
-				text += fmt.Sprintf("<h2>%s</h2>\n", strings.TrimSpace(string(parser.buffer[node.up.begin:node.up.end])))
-			case ruleheading1:
-				text += fmt.Sprintf("<h1>%s</h1>\n", strings.TrimSpace(string(parser.buffer[node.up.begin:node.up.end])))
-			case rulehr:
-				text += fmt.Sprintf("<hr/>\n")
-			case rulebr:
-				text += fmt.Sprintf("<br/>\n\n")
+			case ruleheading6, ruleheading5, ruleheading4, ruleheading3, ruleheading2, ruleheading1:
+				text.WriteString("\n")
+				text.WriteString(inline(strings.TrimSpace(string(parser.buffer[node.up.begin:node.up.end]))))
+				text.WriteString("\n")
+			case rulehr, rulebr:
+				text.WriteString("\n")
 			case rulefree:
 				link(node)
-			case rulecite:
-				text += fmt.Sprintf("<sup class=\"tooltip\">%d<span class=\"tooltiptext\">%s</span></sup>",
-					cite,
-					strings.TrimSpace(string(parser.buffer[node.begin:node.end])))
-				cite++
+			case rulequote:
+				text.WriteString(strings.Repeat("'", formatted[node.begin].Literal))
 			case rulelist:
 				list(node)
+			case ruletable:
+				table(node)
 			case rulewild:
-				text += string(parser.buffer[node.begin:node.end])
+				text.WriteString(string(parser.buffer[node.begin:node.end]))
 			}
-			node = node.next
 		}
 	}
-	ast := parser.AST()
-	node := ast.up
-	for node != nil {
-		switch node.pegRule {
-		case ruleelement:
+	for node := parser.AST().up; node != nil; node = node.next {
+		if node.pegRule == ruleelement {
 			element(node)
 		}
-		node = node.next
 	}
-	return text
+	return text.String(), nil
 }
 
-// HTML returns the HTML version of the article
-func (a *Article) HTML() string {
+// HTML returns the HTML version of the article, the templates aren't expanded
+func (a *Article) HTML() (string, error) {
 	return WikiTextToHTML(a.Text)
 }
 
-// Lookup looks up an article
-func (e *Encyclopedia) Lookup(title string) (article *Article) {
+// Render returns the HTML version of an article with its templates expanded,
+// the infobox of the article is rendered as a side table before the text
+func (e *Encyclopedia) Render(article *Article) (string, error) {
+	infobox, text, err := ParseInfobox(article.Text, e.Expand)
+	if err != nil {
+		return "", err
+	}
+	text, err = e.Expand(text)
+	if err != nil {
+		return "", err
+	}
+	html, err := WikiTextToHTML(text)
+	if err != nil || infobox == nil {
+		return html, err
+	}
+	table, err := renderInfobox(article.Title, infobox)
+	if err != nil {
+		return "", err
+	}
+	return table + html, nil
+}
+
+// PlainText returns the plain text version of the article
+func (a *Article) PlainText() (string, error) {
+	return WikiTextToText(a.Text)
+}
+
+// Checksum returns the sha1 of the text in base 36 as it is found in a dump
+func Checksum(text string) string {
+	sum := sha1.Sum([]byte(text))
+	checksum := new(big.Int).SetBytes(sum[:]).Text(36)
+	return strings.Repeat("0", 31-len(checksum)) + checksum
+}
+
+// Verify returns true if the text of the article matches the sha1 of its
+// revision
+func (a *Article) Verify() bool {
+	return a.SHA1 == Checksum(a.Text)
+}
+
+// Contributor returns the username of the contributor of the revision or
+// the ip address if the contributor is anonymous
+func (a *Article) Contributor() string {
+	if a.Username != "" {
+		return a.Username
+	}
+	return a.IP
+}
+
+// Lookup looks up an article, redirects are followed and the redirect and its
+// section anchor are reported in the article. A title that isn't found is
+// normalized with NormalizeTitle and looked up again, which is an
+// AmbiguousError if several articles have the normalized title.
+func (e *Encyclopedia) Lookup(title string) (article *Article, err error) {
 	db := e.DB
-	err := db.View(func(tx *bolt.Tx) error {
+	err = db.View(func(tx *bolt.Tx) error {
 		wiki := tx.Bucket([]byte("wiki"))
+		titles := tx.Bucket([]byte("titles"))
 		pages := tx.Bucket([]byte("pages"))
-		value := wiki.Get([]byte(title))
-		if value != nil {
-			value := pages.Get(value)
-			compressed := Compressed{}
-			err := proto.Unmarshal(value, &compressed)
-			if err != nil {
-				return err
+		redirects := tx.Bucket([]byte("redirects"))
+		redirect, anchor := "", ""
+		for i := 0; i <= MaxRedirects; i++ {
+			value := wiki.Get([]byte(title))
+			if value != nil {
+				var err error
+				article, err = getArticle(pages, value)
+				if err != nil {
+					return err
+				}
+				if article != nil {
+					article.Redirect, article.Anchor = redirect, anchor
+				}
+				return nil
 			}
-			pressed, output := bytes.NewReader(compressed.Data), make([]byte, compressed.Size)
-			compress.Mark1Decompress16(pressed, output)
-			a := Article{}
-			err = proto.Unmarshal(output, &a)
+			var target []byte
+			if redirects != nil {
+				target = redirects.Get([]byte(title))
+			}
+			if target == nil {
+				// the titles that lead to the same article are one match, and
+				// the article itself is preferred to its redirects
+				matches, distinct := normalizedTitles(titles, title), make(map[string]string)
+				for _, match := range matches {
+					key, last := resolveTitle(wiki, redirects, match)
+					id := "\x00" + last
+					if key != nil {
+						id = string(key)
+					}
+					if _, has := distinct[id]; !has || wiki.Get([]byte(match)) != nil {
+						distinct[id] = match
+					}
+				}
+				if len(distinct) > 1 {
+					return &AmbiguousError{
+						Title:  title,
+						Titles: matches,
+					}
+				}
+				next := ""
+				for _, match := range distinct {
+					next = match
+				}
+				if next == "" || next == title {
+					return nil
+				}
+				title = next
+				continue
+			}
+			if redirect == "" {
+				redirect = title
+			}
+			var a string
+			title, a = SplitAnchor(string(target))
+			if a != "" {
+				anchor = a
+			}
+		}
+		return fmt.Errorf("too many redirects for %s", redirect)
+	})
+	return article, err
+}
+
+// Backlinks returns the titles of the articles that link to an article,
+// redirects are followed as in Lookup. The backlinks are computed by Rank,
+// and they are nil if the article isn't found. The titles are read from the
+// pages of the indexes of the backlinks, so the articles deleted since are
+// left out.
+func (e *Encyclopedia) Backlinks(title string) ([]string, error) {
+	article, err := e.Lookup(title)
+	if err != nil || article == nil {
+		return nil, err
+	}
+	var titles []string
+	err = e.DB.View(func(tx *bolt.Tx) error {
+		key := tx.Bucket([]byte("wiki")).Get([]byte(article.Title))
+		if key == nil {
+			return nil
+		}
+		links, err := getBacklinks(tx.Bucket([]byte("backlinks")), key)
+		if err != nil {
+			return err
+		}
+		pages, source := tx.Bucket([]byte("pages")), make([]byte, 4)
+		titles = make([]string, 0, len(links.Indexes))
+		for _, index := range links.Indexes {
+			binary.LittleEndian.PutUint32(source, index)
+			article, err := getArticle(pages, source)
 			if err != nil {
 				return err
+			} else if article != nil {
+				titles = append(titles, article.Title)
 			}
-			article = &a
 		}
+		sort.Strings(titles)
 		return nil
 	})
-	if err != nil {
-		panic(err)
-	}
-	return article
+	return titles, err
 }
 
-// Search search for a page
-func (e *Encyclopedia) Search(query string) []Result {
-	db := e.DB
-	parts, results := strings.Split(query, " "), make([]Result, 0, 8)
-	err := db.View(func(tx *bolt.Tx) error {
+// SearchRequest is a query and the page of its results to return
+type SearchRequest struct {
+	// Query is the text of the query, it is parsed by query.Parse
+	Query string
+	// Namespaces restrict the results to the namespaces if any are given
+	Namespaces []int32
+	// Offset is the number of results to skip
+	Offset int
+	// Limit is the number of results to return, DefaultLimit if zero
+	Limit int
+	// Candidates is the maximum number of matching articles that are scored,
+	// DefaultCandidates if zero
+	Candidates int
+	// Exact answers the query as it is without correcting misspelled words
+	Exact bool
+}
+
+// Results are a page of the results of a search
+type Results struct {
+	// Total is the number of matching articles in the namespaces
+	Total int
+	// Scored is the number of matching articles that are scored, the results
+	// are the scored articles
+	Scored int
+	// Results are the results of the page
+	Results []Result
+	// Suggestion is the query with the misspelled words corrected, if any
+	Suggestion string
+	// Corrected is true if the results are of the suggestion because the
+	// query matches nothing
+	Corrected bool
+}
+
+// Search search for a page of the results of a query. The query is answered
+// from the positions in the index, and every clause of the query that isn't
+// negated is scored with BM25 and the rank of the article relative to the
+// uniform rank is added with the weight of the options. The matching articles
+// in the namespaces with the highest ranks are scored up to the candidates of
+// the request, and only the articles of the page are read from the db. The misspelled
+// words of the query are corrected into a suggestion, which is answered
+// instead if the query matches nothing.
+func (e *Encyclopedia) Search(request SearchRequest) (*Results, error) {
+	db, options := e.DB, e.Options.defaults()
+	k1, b, weight := *options.K1, *options.B, *options.Weight
+	if request.Limit <= 0 {
+		request.Limit = DefaultLimit
+	}
+	if request.Candidates <= 0 {
+		request.Candidates = DefaultCandidates
+	}
+	if request.Offset < 0 {
+		request.Offset = 0
+	}
+	tree, err := query.Parse(request.Query)
+	if err != nil {
+		return nil, &QueryError{Query: request.Query, Err: err}
+	}
+	if tree != nil && !options.Regex {
+		literal(tree)
+	}
+	results := &Results{}
+	err = db.View(func(tx *bolt.Tx) error {
+		wikiBucket := tx.Bucket([]byte("wiki"))
+		titlesBucket := tx.Bucket([]byte("titles"))
 		pagesBucket := tx.Bucket([]byte("pages"))
+		documentsBucket := tx.Bucket([]byte("documents"))
 		indexBucket := tx.Bucket([]byte("index"))
 		ranksBucket := tx.Bucket([]byte("ranks"))
-		indexes := make(map[uint32]int)
-		for _, part := range parts {
-			part = strings.ToLower(strings.TrimSpace(part))
-			value := indexBucket.Get([]byte(part))
-			if len(value) > 0 {
-				compressed := Compressed{}
-				err := proto.Unmarshal(value, &compressed)
-				if err != nil {
-					return err
+		statistics := getStatistics(tx.Bucket([]byte("meta")))
+		documents, average := float64(statistics.Documents), 1.0
+		if statistics.Documents > 0 && statistics.Length > 0 {
+			average = float64(statistics.Length) / documents
+		}
+		score := func(result *Result) float64 {
+			return result.Score + weight*math.Log1p(float64(result.Rank)*documents)
+		}
+
+		cache := termCache{
+			idx:     indexBucket,
+			terms:   make(map[string]map[uint32][]uint32),
+			regexes: make(map[string][]string),
+		}
+		evaluate := func(tree *query.Node) (*evaluator, set, error) {
+			evaluator := &evaluator{
+				analyzer: e.Analyzer,
+				cache:    cache,
+				terms:    make(map[string]bool),
+			}
+			if tree == nil {
+				return evaluator, nil, nil
+			}
+			matches, err := evaluator.evaluate(tree, false)
+			return evaluator, matches, err
+		}
+		evaluator, matches, err := evaluate(tree)
+		if err != nil {
+			return err
+		}
+		// the words without postings are corrected, and the corrected query is
+		// answered if the query matches nothing
+		if tree != nil && !request.Exact {
+			corrected, suggestion, err := e.Analyzer.corrections(tx.Bucket([]byte("trigrams")), &cache, tree, request.Query)
+			if err != nil {
+				return err
+			}
+			if corrected != nil {
+				results.Suggestion = suggestion
+				if len(matches) == 0 {
+					evaluator, matches, err = evaluate(corrected)
+					if err != nil {
+						return err
+					}
+					results.Corrected = true
 				}
-				pressed, output := bytes.NewReader(compressed.Data), make([]byte, compressed.Size)
-				Decompress(pressed, output)
-				values := Index{}
-				err = proto.Unmarshal(output, &values)
-				if err != nil {
-					return err
+			}
+		}
+		// the candidates are the matching articles in the namespaces, the
+		// articles titled with the query are ranked first
+		type Candidate struct {
+			Result
+			Length float64
+		}
+		// the redirects of the titles lead to the titled articles
+		titled := make(map[uint32]bool)
+		for _, title := range normalizedTitles(titlesBucket, request.Query) {
+			if value, _ := resolveTitle(wikiBucket, tx.Bucket([]byte("redirects")), title); len(value) == 4 {
+				titled[binary.LittleEndian.Uint32(value)] = true
+			}
+		}
+		matching := make([]*Candidate, 0, len(matches))
+		for index := range matches {
+			key := make([]byte, 4)
+			binary.LittleEndian.PutUint32(key, index)
+			document, err := getDocument(documentsBucket, key)
+			if err != nil {
+				return err
+			}
+			included := len(request.Namespaces) == 0
+			for _, namespace := range request.Namespaces {
+				if document.Namespace == namespace {
+					included = true
 				}
-				index := values.Indexes[len(values.Indexes)-1]
-				indexes[index]++
-				for i := len(values.Indexes) - 2; i >= 0; i-- {
-					index -= values.Indexes[i]
-					indexes[index]++
+			}
+			if !included {
+				continue
+			}
+			c := Candidate{
+				Result: Result{
+					Index: index,
+				},
+				Length: float64(document.Length),
+			}
+			if ranksBucket != nil {
+				if rank := ranksBucket.Get(key); len(rank) > 0 {
+					c.Rank = math.Float32frombits(binary.LittleEndian.Uint32(rank))
 				}
 			}
+			if titled[index] {
+				c.Rank = 1
+			}
+			matching = append(matching, &c)
+		}
+		results.Total = len(matching)
+		// the candidates with the highest ranks are scored
+		if len(matching) > request.Candidates {
+			sort.Slice(matching, func(i, j int) bool {
+				if matching[i].Rank == matching[j].Rank {
+					return matching[i].Index < matching[j].Index
+				}
+				return matching[j].Rank < matching[i].Rank
+			})
+			matching = matching[:request.Candidates]
 		}
+		candidates := make(map[uint32]*Candidate, len(matching))
+		for _, c := range matching {
+			candidates[c.Index] = c
+		}
+		results.Scored = len(candidates)
 
-		for index, count := range indexes {
-			value := make([]byte, 4)
-			binary.LittleEndian.PutUint32(value, uint32(index))
-			rank := ranksBucket.Get(value)
-			var r float32
-			if len(rank) > 0 {
-				r = math.Float32frombits(binary.LittleEndian.Uint32(rank))
+		for _, spans := range evaluator.matches {
+			df := float64(len(spans))
+			n := math.Max(documents, df)
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			for index, s := range spans {
+				c, has := candidates[index]
+				if !has {
+					continue
+				}
+				tf := float64(len(s))
+				c.Count++
+				c.Matches += len(s)
+				c.Score += idf * tf * (k1 + 1) /
+					(tf + k1*(1-b+b*c.Length/average))
 			}
-			results = append(results, Result{
-				Index: index,
-				Count: count,
-				Rank:  r,
-			})
 		}
-		sort.Slice(results, func(i, j int) bool {
-			return results[j].Rank < results[i].Rank
+
+		all := make([]Result, 0, len(candidates))
+		for _, c := range candidates {
+			c.Score = score(&c.Result)
+			all = append(all, c.Result)
+		}
+		sort.Slice(all, func(i, j int) bool {
+			if all[i].Score == all[j].Score {
+				return all[i].Index < all[j].Index
+			}
+			return all[j].Score < all[i].Score
 		})
-		if len(results) > 1024 {
-			results = results[:1024]
+		if request.Offset >= len(all) {
+			return nil
+		}
+		page := all[request.Offset:]
+		if len(page) > request.Limit {
+			page = page[:request.Limit]
+		}
+
+		// the articles are read in the transaction and then decompressed in
+		// parallel
+		values := make([][]byte, len(page))
+		for i, result := range page {
+			key := make([]byte, 4)
+			binary.LittleEndian.PutUint32(key, result.Index)
+			values[i] = pagesBucket.Get(key)
 		}
 		done := make(chan error, 8)
-		process := func(result *Result) {
-			index := make([]byte, 4)
-			binary.LittleEndian.PutUint32(index, uint32(result.Index))
-			value := pagesBucket.Get(index)
+		process := func(result *Result, value []byte) {
 			compressed := Compressed{}
 			err := proto.Unmarshal(value, &compressed)
 			if err != nil {
@@ -791,39 +2042,31 @@ func (e *Encyclopedia) Search(query string) []Result {
 				return
 			}
 			result.Article = article
-			if strings.ToLower(article.Title) == strings.ToLower(query) {
-				result.Rank = 1
-			}
-			for _, part := range parts {
-				part = strings.ToLower(strings.TrimSpace(part))
-				exp := regexp.MustCompile(part)
-				matches := exp.FindAllStringIndex(strings.ToLower(article.Text), -1)
-				result.Matches += len(matches)
+			plain, err := snippetText(article.Text)
+			if err != nil {
+				done <- err
+				return
 			}
+			result.Snippets = e.Analyzer.Snippets(plain, evaluator.terms)
 			done <- nil
 		}
-		for i := range results {
-			go process(&results[i])
+		for i := range page {
+			go process(&page[i], values[i])
 		}
-		for range results {
-			err := <-done
-			if err != nil {
-				return err
+		var failed error
+		for range page {
+			if err := <-done; err != nil {
+				failed = err
 			}
 		}
-
-		sort.Slice(results, func(i, j int) bool {
-			if results[j].Count < results[i].Count {
-				return true
-			} else if results[j].Count == results[i].Count {
-				return results[j].Rank*float32(results[j].Matches) < results[i].Rank*float32(results[i].Matches)
-			}
-			return false
-		})
+		if failed != nil {
+			return failed
+		}
+		results.Results = page
 		return nil
 	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	return results
+	return results, nil
 }
@@ -24,12 +24,31 @@ const (
 	rulecite
 	rulelink
 	ruletext
+	rulequote
+	ruleformat
+	ruleformat_close
+	ruleformat_name
 	ruleheading1
 	ruleheading2
 	ruleheading3
 	ruleheading4
 	ruleheading5
 	ruleheading6
+	ruletable
+	ruletable_attributes
+	ruletable_line
+	ruletable_text
+	ruletable_caption
+	ruletable_row
+	ruletable_headers
+	ruletable_header
+	ruletable_cells
+	ruletable_cell
+	rulecell_attributes
+	rulecell_content
+	ruleheader_content
+	ruleline_start
+	ruletable_content
 	rulehr
 	rulebr
 	rulelist_content
@@ -56,12 +75,31 @@ var rul3s = [...]string{
 	"cite",
 	"link",
 	"text",
+	"quote",
+	"format",
+	"format_close",
+	"format_name",
 	"heading1",
 	"heading2",
 	"heading3",
 	"heading4",
 	"heading5",
 	"heading6",
+	"table",
+	"table_attributes",
+	"table_line",
+	"table_text",
+	"table_caption",
+	"table_row",
+	"table_headers",
+	"table_header",
+	"table_cells",
+	"table_cell",
+	"cell_attributes",
+	"cell_content",
+	"header_content",
+	"line_start",
+	"table_content",
 	"hr",
 	"br",
 	"list_content",
@@ -192,7 +230,7 @@ func (t *tokens32) Tokens() []token32 {
 type Wikipedia struct {
 	Buffer string
 	buffer []rune
-	rules  [29]func() bool
+	rules  [48]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -362,6 +400,14 @@ func (p *Wikipedia) Init(options ...func(*Wikipedia) error) error {
 		return false
 	}*/
 
+	/*matchRange := func(lower byte, upper byte) bool {
+		if c := buffer[position]; c >= lower && c <= upper {
+			position++
+			return true
+		}
+		return false
+	}*/
+
 	_rules = [...]func() bool{
 		nil,
 		/* 0 wiki <- <element*> */
@@ -382,7 +428,7 @@ func (p *Wikipedia) Init(options ...func(*Wikipedia) error) error {
 			}
 			return true
 		},
-		/* 1 element <- <(heading6 / heading5 / heading4 / heading3 / heading2 / heading1 / hr / br / list / free / cite / wild)> */
+		/* 1 element <- <(heading6 / heading5 / heading4 / heading3 / heading2 / heading1 / hr / br / list / table / free / cite / quote / format / wild)> */
 		func() bool {
 			position4, tokenIndex4 := position, tokenIndex
 			{
@@ -443,17 +489,35 @@ func (p *Wikipedia) Init(options ...func(*Wikipedia) error) error {
 					goto l6
 				l15:
 					position, tokenIndex = position6, tokenIndex6
-					if !_rules[rulefree]() {
+					if !_rules[ruletable]() {
 						goto l16
 					}
 					goto l6
 				l16:
 					position, tokenIndex = position6, tokenIndex6
-					if !_rules[rulecite]() {
+					if !_rules[rulefree]() {
 						goto l17
 					}
 					goto l6
 				l17:
+					position, tokenIndex = position6, tokenIndex6
+					if !_rules[rulecite]() {
+						goto l18
+					}
+					goto l6
+				l18:
+					position, tokenIndex = position6, tokenIndex6
+					if !_rules[rulequote]() {
+						goto l19
+					}
+					goto l6
+				l19:
+					position, tokenIndex = position6, tokenIndex6
+					if !_rules[ruleformat]() {
+						goto l20
+					}
+					goto l6
+				l20:
 					position, tokenIndex = position6, tokenIndex6
 					if !_rules[rulewild]() {
 						goto l4
@@ -469,1597 +533,2926 @@ func (p *Wikipedia) Init(options ...func(*Wikipedia) error) error {
 		},
 		/* 2 free <- <('[' '[' link ('|' text)? (']' ']'))> */
 		func() bool {
-			position18, tokenIndex18 := position, tokenIndex
+			position21, tokenIndex21 := position, tokenIndex
 			{
-				position19 := position
+				position22 := position
 				if buffer[position] != rune('[') {
-					goto l18
+					goto l21
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l18
+					goto l21
 				}
 				position++
 				if !_rules[rulelink]() {
-					goto l18
+					goto l21
 				}
 				{
-					position20, tokenIndex20 := position, tokenIndex
+					position23, tokenIndex23 := position, tokenIndex
 					if buffer[position] != rune('|') {
-						goto l20
+						goto l23
 					}
 					position++
 					if !_rules[ruletext]() {
-						goto l20
+						goto l23
 					}
-					goto l21
-				l20:
-					position, tokenIndex = position20, tokenIndex20
+					goto l24
+				l23:
+					position, tokenIndex = position23, tokenIndex23
 				}
-			l21:
+			l24:
 				if buffer[position] != rune(']') {
-					goto l18
+					goto l21
 				}
 				position++
 				if buffer[position] != rune(']') {
-					goto l18
+					goto l21
 				}
 				position++
-				add(rulefree, position19)
+				add(rulefree, position22)
 			}
 			return true
-		l18:
-			position, tokenIndex = position18, tokenIndex18
+		l21:
+			position, tokenIndex = position21, tokenIndex21
 			return false
 		},
 		/* 3 cite <- <('<' 'r' 'e' 'f' '>' '{' '{' 'c' 'i' 't' 'e' ' ' (!'|' .)+ ('|' (!'=' .)+ '=' (!('|' / '}') .)+)* ('}' '}' '<' '/' 'r' 'e' 'f' '>'))> */
 		func() bool {
-			position22, tokenIndex22 := position, tokenIndex
+			position25, tokenIndex25 := position, tokenIndex
 			{
-				position23 := position
+				position26 := position
 				if buffer[position] != rune('<') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('f') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('>') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('{') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('{') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('i') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune(' ') {
-					goto l22
+					goto l25
 				}
 				position++
 				{
-					position26, tokenIndex26 := position, tokenIndex
+					position29, tokenIndex29 := position, tokenIndex
 					if buffer[position] != rune('|') {
-						goto l26
+						goto l29
 					}
 					position++
-					goto l22
-				l26:
-					position, tokenIndex = position26, tokenIndex26
+					goto l25
+				l29:
+					position, tokenIndex = position29, tokenIndex29
 				}
 				if !matchDot() {
-					goto l22
+					goto l25
 				}
-			l24:
+			l27:
 				{
-					position25, tokenIndex25 := position, tokenIndex
+					position28, tokenIndex28 := position, tokenIndex
 					{
-						position27, tokenIndex27 := position, tokenIndex
+						position30, tokenIndex30 := position, tokenIndex
 						if buffer[position] != rune('|') {
-							goto l27
+							goto l30
 						}
 						position++
-						goto l25
-					l27:
-						position, tokenIndex = position27, tokenIndex27
+						goto l28
+					l30:
+						position, tokenIndex = position30, tokenIndex30
 					}
 					if !matchDot() {
-						goto l25
+						goto l28
 					}
-					goto l24
-				l25:
-					position, tokenIndex = position25, tokenIndex25
+					goto l27
+				l28:
+					position, tokenIndex = position28, tokenIndex28
 				}
-			l28:
+			l31:
 				{
-					position29, tokenIndex29 := position, tokenIndex
+					position32, tokenIndex32 := position, tokenIndex
 					if buffer[position] != rune('|') {
-						goto l29
+						goto l32
 					}
 					position++
 					{
-						position32, tokenIndex32 := position, tokenIndex
+						position35, tokenIndex35 := position, tokenIndex
 						if buffer[position] != rune('=') {
-							goto l32
+							goto l35
 						}
 						position++
-						goto l29
-					l32:
-						position, tokenIndex = position32, tokenIndex32
+						goto l32
+					l35:
+						position, tokenIndex = position35, tokenIndex35
 					}
 					if !matchDot() {
-						goto l29
+						goto l32
 					}
-				l30:
+				l33:
 					{
-						position31, tokenIndex31 := position, tokenIndex
+						position34, tokenIndex34 := position, tokenIndex
 						{
-							position33, tokenIndex33 := position, tokenIndex
+							position36, tokenIndex36 := position, tokenIndex
 							if buffer[position] != rune('=') {
-								goto l33
+								goto l36
 							}
 							position++
-							goto l31
-						l33:
-							position, tokenIndex = position33, tokenIndex33
+							goto l34
+						l36:
+							position, tokenIndex = position36, tokenIndex36
 						}
 						if !matchDot() {
-							goto l31
+							goto l34
 						}
-						goto l30
-					l31:
-						position, tokenIndex = position31, tokenIndex31
+						goto l33
+					l34:
+						position, tokenIndex = position34, tokenIndex34
 					}
 					if buffer[position] != rune('=') {
-						goto l29
+						goto l32
 					}
 					position++
 					{
-						position36, tokenIndex36 := position, tokenIndex
+						position39, tokenIndex39 := position, tokenIndex
 						{
-							position37, tokenIndex37 := position, tokenIndex
+							position40, tokenIndex40 := position, tokenIndex
 							if buffer[position] != rune('|') {
-								goto l38
+								goto l41
 							}
 							position++
-							goto l37
-						l38:
-							position, tokenIndex = position37, tokenIndex37
+							goto l40
+						l41:
+							position, tokenIndex = position40, tokenIndex40
 							if buffer[position] != rune('}') {
-								goto l36
+								goto l39
 							}
 							position++
 						}
-					l37:
-						goto l29
-					l36:
-						position, tokenIndex = position36, tokenIndex36
+					l40:
+						goto l32
+					l39:
+						position, tokenIndex = position39, tokenIndex39
 					}
 					if !matchDot() {
-						goto l29
+						goto l32
 					}
-				l34:
+				l37:
 					{
-						position35, tokenIndex35 := position, tokenIndex
+						position38, tokenIndex38 := position, tokenIndex
 						{
-							position39, tokenIndex39 := position, tokenIndex
+							position42, tokenIndex42 := position, tokenIndex
 							{
-								position40, tokenIndex40 := position, tokenIndex
+								position43, tokenIndex43 := position, tokenIndex
 								if buffer[position] != rune('|') {
-									goto l41
+									goto l44
 								}
 								position++
-								goto l40
-							l41:
-								position, tokenIndex = position40, tokenIndex40
+								goto l43
+							l44:
+								position, tokenIndex = position43, tokenIndex43
 								if buffer[position] != rune('}') {
-									goto l39
+									goto l42
 								}
 								position++
 							}
-						l40:
-							goto l35
-						l39:
-							position, tokenIndex = position39, tokenIndex39
+						l43:
+							goto l38
+						l42:
+							position, tokenIndex = position42, tokenIndex42
 						}
 						if !matchDot() {
-							goto l35
+							goto l38
 						}
-						goto l34
-					l35:
-						position, tokenIndex = position35, tokenIndex35
+						goto l37
+					l38:
+						position, tokenIndex = position38, tokenIndex38
 					}
-					goto l28
-				l29:
-					position, tokenIndex = position29, tokenIndex29
+					goto l31
+				l32:
+					position, tokenIndex = position32, tokenIndex32
 				}
 				if buffer[position] != rune('}') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('}') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('<') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('/') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('f') {
-					goto l22
+					goto l25
 				}
 				position++
 				if buffer[position] != rune('>') {
-					goto l22
+					goto l25
 				}
 				position++
-				add(rulecite, position23)
+				add(rulecite, position26)
 			}
 			return true
-		l22:
-			position, tokenIndex = position22, tokenIndex22
+		l25:
+			position, tokenIndex = position25, tokenIndex25
 			return false
 		},
 		/* 4 link <- <(!('|' / (']' ']')) .)*> */
 		func() bool {
 			{
-				position43 := position
-			l44:
+				position46 := position
+			l47:
 				{
-					position45, tokenIndex45 := position, tokenIndex
+					position48, tokenIndex48 := position, tokenIndex
 					{
-						position46, tokenIndex46 := position, tokenIndex
+						position49, tokenIndex49 := position, tokenIndex
 						{
-							position47, tokenIndex47 := position, tokenIndex
+							position50, tokenIndex50 := position, tokenIndex
 							if buffer[position] != rune('|') {
-								goto l48
+								goto l51
 							}
 							position++
-							goto l47
-						l48:
-							position, tokenIndex = position47, tokenIndex47
+							goto l50
+						l51:
+							position, tokenIndex = position50, tokenIndex50
 							if buffer[position] != rune(']') {
-								goto l46
+								goto l49
 							}
 							position++
 							if buffer[position] != rune(']') {
-								goto l46
+								goto l49
 							}
 							position++
 						}
-					l47:
-						goto l45
-					l46:
-						position, tokenIndex = position46, tokenIndex46
+					l50:
+						goto l48
+					l49:
+						position, tokenIndex = position49, tokenIndex49
 					}
 					if !matchDot() {
-						goto l45
+						goto l48
 					}
-					goto l44
-				l45:
-					position, tokenIndex = position45, tokenIndex45
+					goto l47
+				l48:
+					position, tokenIndex = position48, tokenIndex48
 				}
-				add(rulelink, position43)
+				add(rulelink, position46)
 			}
 			return true
 		},
 		/* 5 text <- <(!('|' / (']' ']')) .)*> */
 		func() bool {
 			{
-				position50 := position
-			l51:
+				position53 := position
+			l54:
 				{
-					position52, tokenIndex52 := position, tokenIndex
+					position55, tokenIndex55 := position, tokenIndex
 					{
-						position53, tokenIndex53 := position, tokenIndex
+						position56, tokenIndex56 := position, tokenIndex
 						{
-							position54, tokenIndex54 := position, tokenIndex
+							position57, tokenIndex57 := position, tokenIndex
 							if buffer[position] != rune('|') {
-								goto l55
+								goto l58
 							}
 							position++
-							goto l54
-						l55:
-							position, tokenIndex = position54, tokenIndex54
+							goto l57
+						l58:
+							position, tokenIndex = position57, tokenIndex57
 							if buffer[position] != rune(']') {
-								goto l53
+								goto l56
 							}
 							position++
 							if buffer[position] != rune(']') {
-								goto l53
+								goto l56
 							}
 							position++
 						}
-					l54:
-						goto l52
-					l53:
-						position, tokenIndex = position53, tokenIndex53
+					l57:
+						goto l55
+					l56:
+						position, tokenIndex = position56, tokenIndex56
 					}
 					if !matchDot() {
-						goto l52
+						goto l55
 					}
-					goto l51
-				l52:
-					position, tokenIndex = position52, tokenIndex52
+					goto l54
+				l55:
+					position, tokenIndex = position55, tokenIndex55
 				}
-				add(ruletext, position50)
+				add(ruletext, position53)
 			}
 			return true
 		},
-		/* 6 heading1 <- <('=' <(!'=' .)+> '=' end)> */
+		/* 6 quote <- <('\'' '\'' '\''*)> */
 		func() bool {
-			position56, tokenIndex56 := position, tokenIndex
+			position59, tokenIndex59 := position, tokenIndex
 			{
-				position57 := position
-				if buffer[position] != rune('=') {
-					goto l56
+				position60 := position
+				if buffer[position] != rune('\'') {
+					goto l59
 				}
 				position++
-				{
-					position58 := position
-					{
-						position61, tokenIndex61 := position, tokenIndex
-						if buffer[position] != rune('=') {
-							goto l61
-						}
-						position++
-						goto l56
-					l61:
-						position, tokenIndex = position61, tokenIndex61
-					}
-					if !matchDot() {
-						goto l56
-					}
-				l59:
-					{
-						position60, tokenIndex60 := position, tokenIndex
-						{
-							position62, tokenIndex62 := position, tokenIndex
-							if buffer[position] != rune('=') {
-								goto l62
-							}
-							position++
-							goto l60
-						l62:
-							position, tokenIndex = position62, tokenIndex62
-						}
-						if !matchDot() {
-							goto l60
-						}
-						goto l59
-					l60:
-						position, tokenIndex = position60, tokenIndex60
-					}
-					add(rulePegText, position58)
-				}
-				if buffer[position] != rune('=') {
-					goto l56
+				if buffer[position] != rune('\'') {
+					goto l59
 				}
 				position++
-				if !_rules[ruleend]() {
-					goto l56
+			l61:
+				{
+					position62, tokenIndex62 := position, tokenIndex
+					if buffer[position] != rune('\'') {
+						goto l62
+					}
+					position++
+					goto l61
+				l62:
+					position, tokenIndex = position62, tokenIndex62
 				}
-				add(ruleheading1, position57)
+				add(rulequote, position60)
 			}
 			return true
-		l56:
-			position, tokenIndex = position56, tokenIndex56
+		l59:
+			position, tokenIndex = position59, tokenIndex59
 			return false
 		},
-		/* 7 heading2 <- <('=' '=' <(!('=' '=') .)+> ('=' '=') end)> */
+		/* 7 format <- <('<' format_close? format_name ((' ' / '\t' / '\r' / '\n') (!'>' .)*)? '/'? '>')> */
 		func() bool {
 			position63, tokenIndex63 := position, tokenIndex
 			{
 				position64 := position
-				if buffer[position] != rune('=') {
+				if buffer[position] != rune('<') {
 					goto l63
 				}
 				position++
-				if buffer[position] != rune('=') {
+				{
+					position65, tokenIndex65 := position, tokenIndex
+					if !_rules[ruleformat_close]() {
+						goto l65
+					}
+					goto l66
+				l65:
+					position, tokenIndex = position65, tokenIndex65
+				}
+			l66:
+				if !_rules[ruleformat_name]() {
 					goto l63
 				}
-				position++
 				{
-					position65 := position
+					position67, tokenIndex67 := position, tokenIndex
 					{
-						position68, tokenIndex68 := position, tokenIndex
-						if buffer[position] != rune('=') {
-							goto l68
+						position69, tokenIndex69 := position, tokenIndex
+						if buffer[position] != rune(' ') {
+							goto l70
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l68
+						goto l69
+					l70:
+						position, tokenIndex = position69, tokenIndex69
+						if buffer[position] != rune('\t') {
+							goto l71
+						}
+						position++
+						goto l69
+					l71:
+						position, tokenIndex = position69, tokenIndex69
+						if buffer[position] != rune('\r') {
+							goto l72
+						}
+						position++
+						goto l69
+					l72:
+						position, tokenIndex = position69, tokenIndex69
+						if buffer[position] != rune('\n') {
+							goto l67
 						}
 						position++
-						goto l63
-					l68:
-						position, tokenIndex = position68, tokenIndex68
-					}
-					if !matchDot() {
-						goto l63
 					}
-				l66:
+				l69:
+				l73:
 					{
-						position67, tokenIndex67 := position, tokenIndex
+						position74, tokenIndex74 := position, tokenIndex
 						{
-							position69, tokenIndex69 := position, tokenIndex
-							if buffer[position] != rune('=') {
-								goto l69
-							}
-							position++
-							if buffer[position] != rune('=') {
-								goto l69
+							position75, tokenIndex75 := position, tokenIndex
+							if buffer[position] != rune('>') {
+								goto l75
 							}
 							position++
-							goto l67
-						l69:
-							position, tokenIndex = position69, tokenIndex69
+							goto l74
+						l75:
+							position, tokenIndex = position75, tokenIndex75
 						}
 						if !matchDot() {
-							goto l67
+							goto l74
 						}
-						goto l66
-					l67:
-						position, tokenIndex = position67, tokenIndex67
+						goto l73
+					l74:
+						position, tokenIndex = position74, tokenIndex74
 					}
-					add(rulePegText, position65)
+					goto l68
+				l67:
+					position, tokenIndex = position67, tokenIndex67
 				}
-				if buffer[position] != rune('=') {
-					goto l63
+			l68:
+				{
+					position76, tokenIndex76 := position, tokenIndex
+					if buffer[position] != rune('/') {
+						goto l76
+					}
+					position++
+					goto l77
+				l76:
+					position, tokenIndex = position76, tokenIndex76
 				}
-				position++
-				if buffer[position] != rune('=') {
+			l77:
+				if buffer[position] != rune('>') {
 					goto l63
 				}
 				position++
-				if !_rules[ruleend]() {
-					goto l63
-				}
-				add(ruleheading2, position64)
+				add(ruleformat, position64)
 			}
 			return true
 		l63:
 			position, tokenIndex = position63, tokenIndex63
 			return false
 		},
-		/* 8 heading3 <- <('=' '=' '=' <(!('=' '=' '=') .)+> ('=' '=' '=') end)> */
+		/* 8 format_close <- <'/'> */
 		func() bool {
-			position70, tokenIndex70 := position, tokenIndex
+			position78, tokenIndex78 := position, tokenIndex
 			{
-				position71 := position
-				if buffer[position] != rune('=') {
-					goto l70
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l70
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l70
+				position79 := position
+				if buffer[position] != rune('/') {
+					goto l78
 				}
 				position++
+				add(ruleformat_close, position79)
+			}
+			return true
+		l78:
+			position, tokenIndex = position78, tokenIndex78
+			return false
+		},
+		/* 9 format_name <- <(((('b' / 'B') ('i' / 'I') ('g' / 'G')) / ('b' / 'B') / ('i' / 'I') / ('u' / 'U') / (('s' / 'S') ('u' / 'U') ('b' / 'B')) / (('s' / 'S') ('u' / 'U') ('p' / 'P')) / (('s' / 'S') ('m' / 'M') ('a' / 'A') ('l' / 'L') ('l' / 'L')) / ('s' / 'S')) !([a-z] / [A-Z] / [0-9]))> */
+		func() bool {
+			position80, tokenIndex80 := position, tokenIndex
+			{
+				position81 := position
 				{
-					position72 := position
+					position82, tokenIndex82 := position, tokenIndex
 					{
-						position75, tokenIndex75 := position, tokenIndex
-						if buffer[position] != rune('=') {
-							goto l75
+						position84, tokenIndex84 := position, tokenIndex
+						if buffer[position] != rune('b') {
+							goto l85
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l75
+						goto l84
+					l85:
+						position, tokenIndex = position84, tokenIndex84
+						if buffer[position] != rune('B') {
+							goto l83
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l75
+					}
+				l84:
+					{
+						position86, tokenIndex86 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l87
+						}
+						position++
+						goto l86
+					l87:
+						position, tokenIndex = position86, tokenIndex86
+						if buffer[position] != rune('I') {
+							goto l83
 						}
 						position++
-						goto l70
-					l75:
-						position, tokenIndex = position75, tokenIndex75
 					}
-					if !matchDot() {
-						goto l70
+				l86:
+					{
+						position88, tokenIndex88 := position, tokenIndex
+						if buffer[position] != rune('g') {
+							goto l89
+						}
+						position++
+						goto l88
+					l89:
+						position, tokenIndex = position88, tokenIndex88
+						if buffer[position] != rune('G') {
+							goto l83
+						}
+						position++
 					}
-				l73:
+				l88:
+					goto l82
+				l83:
+					position, tokenIndex = position82, tokenIndex82
 					{
-						position74, tokenIndex74 := position, tokenIndex
-						{
-							position76, tokenIndex76 := position, tokenIndex
-							if buffer[position] != rune('=') {
-								goto l76
-							}
-							position++
-							if buffer[position] != rune('=') {
-								goto l76
-							}
-							position++
-							if buffer[position] != rune('=') {
-								goto l76
-							}
-							position++
-							goto l74
-						l76:
-							position, tokenIndex = position76, tokenIndex76
+						position91, tokenIndex91 := position, tokenIndex
+						if buffer[position] != rune('b') {
+							goto l92
 						}
-						if !matchDot() {
-							goto l74
+						position++
+						goto l91
+					l92:
+						position, tokenIndex = position91, tokenIndex91
+						if buffer[position] != rune('B') {
+							goto l90
 						}
-						goto l73
-					l74:
-						position, tokenIndex = position74, tokenIndex74
+						position++
 					}
-					add(rulePegText, position72)
-				}
-				if buffer[position] != rune('=') {
-					goto l70
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l70
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l70
-				}
-				position++
-				if !_rules[ruleend]() {
-					goto l70
-				}
-				add(ruleheading3, position71)
-			}
-			return true
-		l70:
-			position, tokenIndex = position70, tokenIndex70
-			return false
-		},
-		/* 9 heading4 <- <('=' '=' '=' '=' <(!('=' '=' '=' '=') .)+> ('=' '=' '=' '=') end)> */
-		func() bool {
-			position77, tokenIndex77 := position, tokenIndex
-			{
-				position78 := position
-				if buffer[position] != rune('=') {
-					goto l77
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l77
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l77
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l77
-				}
-				position++
-				{
-					position79 := position
+				l91:
+					goto l82
+				l90:
+					position, tokenIndex = position82, tokenIndex82
 					{
-						position82, tokenIndex82 := position, tokenIndex
-						if buffer[position] != rune('=') {
-							goto l82
+						position94, tokenIndex94 := position, tokenIndex
+						if buffer[position] != rune('i') {
+							goto l95
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l82
+						goto l94
+					l95:
+						position, tokenIndex = position94, tokenIndex94
+						if buffer[position] != rune('I') {
+							goto l93
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l82
+					}
+				l94:
+					goto l82
+				l93:
+					position, tokenIndex = position82, tokenIndex82
+					{
+						position97, tokenIndex97 := position, tokenIndex
+						if buffer[position] != rune('u') {
+							goto l98
 						}
 						position++
-						if buffer[position] != rune('=') {
-							goto l82
+						goto l97
+					l98:
+						position, tokenIndex = position97, tokenIndex97
+						if buffer[position] != rune('U') {
+							goto l96
 						}
 						position++
-						goto l77
-					l82:
-						position, tokenIndex = position82, tokenIndex82
 					}
-					if !matchDot() {
-						goto l77
+				l97:
+					goto l82
+				l96:
+					position, tokenIndex = position82, tokenIndex82
+					{
+						position100, tokenIndex100 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l101
+						}
+						position++
+						goto l100
+					l101:
+						position, tokenIndex = position100, tokenIndex100
+						if buffer[position] != rune('S') {
+							goto l99
+						}
+						position++
 					}
-				l80:
+				l100:
 					{
-						position81, tokenIndex81 := position, tokenIndex
-						{
-							position83, tokenIndex83 := position, tokenIndex
-							if buffer[position] != rune('=') {
-								goto l83
-							}
-							position++
-							if buffer[position] != rune('=') {
-								goto l83
-							}
-							position++
-							if buffer[position] != rune('=') {
-								goto l83
-							}
-							position++
-							if buffer[position] != rune('=') {
-								goto l83
-							}
-							position++
-							goto l81
-						l83:
-							position, tokenIndex = position83, tokenIndex83
+						position102, tokenIndex102 := position, tokenIndex
+						if buffer[position] != rune('u') {
+							goto l103
 						}
-						if !matchDot() {
-							goto l81
+						position++
+						goto l102
+					l103:
+						position, tokenIndex = position102, tokenIndex102
+						if buffer[position] != rune('U') {
+							goto l99
 						}
-						goto l80
-					l81:
-						position, tokenIndex = position81, tokenIndex81
+						position++
 					}
-					add(rulePegText, position79)
-				}
-				if buffer[position] != rune('=') {
-					goto l77
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l77
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l77
+				l102:
+					{
+						position104, tokenIndex104 := position, tokenIndex
+						if buffer[position] != rune('b') {
+							goto l105
+						}
+						position++
+						goto l104
+					l105:
+						position, tokenIndex = position104, tokenIndex104
+						if buffer[position] != rune('B') {
+							goto l99
+						}
+						position++
+					}
+				l104:
+					goto l82
+				l99:
+					position, tokenIndex = position82, tokenIndex82
+					{
+						position107, tokenIndex107 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l108
+						}
+						position++
+						goto l107
+					l108:
+						position, tokenIndex = position107, tokenIndex107
+						if buffer[position] != rune('S') {
+							goto l106
+						}
+						position++
+					}
+				l107:
+					{
+						position109, tokenIndex109 := position, tokenIndex
+						if buffer[position] != rune('u') {
+							goto l110
+						}
+						position++
+						goto l109
+					l110:
+						position, tokenIndex = position109, tokenIndex109
+						if buffer[position] != rune('U') {
+							goto l106
+						}
+						position++
+					}
+				l109:
+					{
+						position111, tokenIndex111 := position, tokenIndex
+						if buffer[position] != rune('p') {
+							goto l112
+						}
+						position++
+						goto l111
+					l112:
+						position, tokenIndex = position111, tokenIndex111
+						if buffer[position] != rune('P') {
+							goto l106
+						}
+						position++
+					}
+				l111:
+					goto l82
+				l106:
+					position, tokenIndex = position82, tokenIndex82
+					{
+						position114, tokenIndex114 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l115
+						}
+						position++
+						goto l114
+					l115:
+						position, tokenIndex = position114, tokenIndex114
+						if buffer[position] != rune('S') {
+							goto l113
+						}
+						position++
+					}
+				l114:
+					{
+						position116, tokenIndex116 := position, tokenIndex
+						if buffer[position] != rune('m') {
+							goto l117
+						}
+						position++
+						goto l116
+					l117:
+						position, tokenIndex = position116, tokenIndex116
+						if buffer[position] != rune('M') {
+							goto l113
+						}
+						position++
+					}
+				l116:
+					{
+						position118, tokenIndex118 := position, tokenIndex
+						if buffer[position] != rune('a') {
+							goto l119
+						}
+						position++
+						goto l118
+					l119:
+						position, tokenIndex = position118, tokenIndex118
+						if buffer[position] != rune('A') {
+							goto l113
+						}
+						position++
+					}
+				l118:
+					{
+						position120, tokenIndex120 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l121
+						}
+						position++
+						goto l120
+					l121:
+						position, tokenIndex = position120, tokenIndex120
+						if buffer[position] != rune('L') {
+							goto l113
+						}
+						position++
+					}
+				l120:
+					{
+						position122, tokenIndex122 := position, tokenIndex
+						if buffer[position] != rune('l') {
+							goto l123
+						}
+						position++
+						goto l122
+					l123:
+						position, tokenIndex = position122, tokenIndex122
+						if buffer[position] != rune('L') {
+							goto l113
+						}
+						position++
+					}
+				l122:
+					goto l82
+				l113:
+					position, tokenIndex = position82, tokenIndex82
+					{
+						position124, tokenIndex124 := position, tokenIndex
+						if buffer[position] != rune('s') {
+							goto l125
+						}
+						position++
+						goto l124
+					l125:
+						position, tokenIndex = position124, tokenIndex124
+						if buffer[position] != rune('S') {
+							goto l80
+						}
+						position++
+					}
+				l124:
+				}
+			l82:
+				{
+					position126, tokenIndex126 := position, tokenIndex
+					{
+						position127, tokenIndex127 := position, tokenIndex
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l128
+						}
+						position++
+						goto l127
+					l128:
+						position, tokenIndex = position127, tokenIndex127
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l129
+						}
+						position++
+						goto l127
+					l129:
+						position, tokenIndex = position127, tokenIndex127
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l126
+						}
+						position++
+					}
+				l127:
+					goto l80
+				l126:
+					position, tokenIndex = position126, tokenIndex126
+				}
+				add(ruleformat_name, position81)
+			}
+			return true
+		l80:
+			position, tokenIndex = position80, tokenIndex80
+			return false
+		},
+		/* 10 heading1 <- <('=' <(!'=' .)+> '=' end)> */
+		func() bool {
+			position130, tokenIndex130 := position, tokenIndex
+			{
+				position131 := position
+				if buffer[position] != rune('=') {
+					goto l130
 				}
 				position++
+				{
+					position132 := position
+					{
+						position135, tokenIndex135 := position, tokenIndex
+						if buffer[position] != rune('=') {
+							goto l135
+						}
+						position++
+						goto l130
+					l135:
+						position, tokenIndex = position135, tokenIndex135
+					}
+					if !matchDot() {
+						goto l130
+					}
+				l133:
+					{
+						position134, tokenIndex134 := position, tokenIndex
+						{
+							position136, tokenIndex136 := position, tokenIndex
+							if buffer[position] != rune('=') {
+								goto l136
+							}
+							position++
+							goto l134
+						l136:
+							position, tokenIndex = position136, tokenIndex136
+						}
+						if !matchDot() {
+							goto l134
+						}
+						goto l133
+					l134:
+						position, tokenIndex = position134, tokenIndex134
+					}
+					add(rulePegText, position132)
+				}
 				if buffer[position] != rune('=') {
-					goto l77
+					goto l130
 				}
 				position++
 				if !_rules[ruleend]() {
-					goto l77
+					goto l130
 				}
-				add(ruleheading4, position78)
+				add(ruleheading1, position131)
 			}
 			return true
-		l77:
-			position, tokenIndex = position77, tokenIndex77
+		l130:
+			position, tokenIndex = position130, tokenIndex130
 			return false
 		},
-		/* 10 heading5 <- <('=' '=' '=' '=' '=' <(!('=' '=' '=' '=' '=') .)+> ('=' '=' '=' '=' '=') end)> */
+		/* 11 heading2 <- <('=' '=' <(!('=' '=') .)+> ('=' '=') end)> */
 		func() bool {
-			position84, tokenIndex84 := position, tokenIndex
+			position137, tokenIndex137 := position, tokenIndex
 			{
-				position85 := position
+				position138 := position
+				if buffer[position] != rune('=') {
+					goto l137
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l137
+				}
+				position++
+				{
+					position139 := position
+					{
+						position142, tokenIndex142 := position, tokenIndex
+						if buffer[position] != rune('=') {
+							goto l142
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l142
+						}
+						position++
+						goto l137
+					l142:
+						position, tokenIndex = position142, tokenIndex142
+					}
+					if !matchDot() {
+						goto l137
+					}
+				l140:
+					{
+						position141, tokenIndex141 := position, tokenIndex
+						{
+							position143, tokenIndex143 := position, tokenIndex
+							if buffer[position] != rune('=') {
+								goto l143
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l143
+							}
+							position++
+							goto l141
+						l143:
+							position, tokenIndex = position143, tokenIndex143
+						}
+						if !matchDot() {
+							goto l141
+						}
+						goto l140
+					l141:
+						position, tokenIndex = position141, tokenIndex141
+					}
+					add(rulePegText, position139)
+				}
 				if buffer[position] != rune('=') {
-					goto l84
+					goto l137
 				}
 				position++
 				if buffer[position] != rune('=') {
-					goto l84
+					goto l137
 				}
 				position++
+				if !_rules[ruleend]() {
+					goto l137
+				}
+				add(ruleheading2, position138)
+			}
+			return true
+		l137:
+			position, tokenIndex = position137, tokenIndex137
+			return false
+		},
+		/* 12 heading3 <- <('=' '=' '=' <(!('=' '=' '=') .)+> ('=' '=' '=') end)> */
+		func() bool {
+			position144, tokenIndex144 := position, tokenIndex
+			{
+				position145 := position
 				if buffer[position] != rune('=') {
-					goto l84
+					goto l144
 				}
 				position++
 				if buffer[position] != rune('=') {
-					goto l84
+					goto l144
 				}
 				position++
 				if buffer[position] != rune('=') {
-					goto l84
+					goto l144
 				}
 				position++
 				{
-					position86 := position
+					position146 := position
 					{
-						position89, tokenIndex89 := position, tokenIndex
+						position149, tokenIndex149 := position, tokenIndex
 						if buffer[position] != rune('=') {
-							goto l89
+							goto l149
 						}
 						position++
 						if buffer[position] != rune('=') {
-							goto l89
+							goto l149
 						}
 						position++
 						if buffer[position] != rune('=') {
-							goto l89
+							goto l149
+						}
+						position++
+						goto l144
+					l149:
+						position, tokenIndex = position149, tokenIndex149
+					}
+					if !matchDot() {
+						goto l144
+					}
+				l147:
+					{
+						position148, tokenIndex148 := position, tokenIndex
+						{
+							position150, tokenIndex150 := position, tokenIndex
+							if buffer[position] != rune('=') {
+								goto l150
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l150
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l150
+							}
+							position++
+							goto l148
+						l150:
+							position, tokenIndex = position150, tokenIndex150
+						}
+						if !matchDot() {
+							goto l148
+						}
+						goto l147
+					l148:
+						position, tokenIndex = position148, tokenIndex148
+					}
+					add(rulePegText, position146)
+				}
+				if buffer[position] != rune('=') {
+					goto l144
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l144
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l144
+				}
+				position++
+				if !_rules[ruleend]() {
+					goto l144
+				}
+				add(ruleheading3, position145)
+			}
+			return true
+		l144:
+			position, tokenIndex = position144, tokenIndex144
+			return false
+		},
+		/* 13 heading4 <- <('=' '=' '=' '=' <(!('=' '=' '=' '=') .)+> ('=' '=' '=' '=') end)> */
+		func() bool {
+			position151, tokenIndex151 := position, tokenIndex
+			{
+				position152 := position
+				if buffer[position] != rune('=') {
+					goto l151
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l151
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l151
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l151
+				}
+				position++
+				{
+					position153 := position
+					{
+						position156, tokenIndex156 := position, tokenIndex
+						if buffer[position] != rune('=') {
+							goto l156
 						}
 						position++
 						if buffer[position] != rune('=') {
-							goto l89
+							goto l156
 						}
 						position++
 						if buffer[position] != rune('=') {
-							goto l89
+							goto l156
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l156
+						}
+						position++
+						goto l151
+					l156:
+						position, tokenIndex = position156, tokenIndex156
+					}
+					if !matchDot() {
+						goto l151
+					}
+				l154:
+					{
+						position155, tokenIndex155 := position, tokenIndex
+						{
+							position157, tokenIndex157 := position, tokenIndex
+							if buffer[position] != rune('=') {
+								goto l157
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l157
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l157
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l157
+							}
+							position++
+							goto l155
+						l157:
+							position, tokenIndex = position157, tokenIndex157
+						}
+						if !matchDot() {
+							goto l155
+						}
+						goto l154
+					l155:
+						position, tokenIndex = position155, tokenIndex155
+					}
+					add(rulePegText, position153)
+				}
+				if buffer[position] != rune('=') {
+					goto l151
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l151
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l151
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l151
+				}
+				position++
+				if !_rules[ruleend]() {
+					goto l151
+				}
+				add(ruleheading4, position152)
+			}
+			return true
+		l151:
+			position, tokenIndex = position151, tokenIndex151
+			return false
+		},
+		/* 14 heading5 <- <('=' '=' '=' '=' '=' <(!('=' '=' '=' '=' '=') .)+> ('=' '=' '=' '=' '=') end)> */
+		func() bool {
+			position158, tokenIndex158 := position, tokenIndex
+			{
+				position159 := position
+				if buffer[position] != rune('=') {
+					goto l158
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l158
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l158
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l158
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l158
+				}
+				position++
+				{
+					position160 := position
+					{
+						position163, tokenIndex163 := position, tokenIndex
+						if buffer[position] != rune('=') {
+							goto l163
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l163
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l163
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l163
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l163
+						}
+						position++
+						goto l158
+					l163:
+						position, tokenIndex = position163, tokenIndex163
+					}
+					if !matchDot() {
+						goto l158
+					}
+				l161:
+					{
+						position162, tokenIndex162 := position, tokenIndex
+						{
+							position164, tokenIndex164 := position, tokenIndex
+							if buffer[position] != rune('=') {
+								goto l164
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l164
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l164
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l164
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l164
+							}
+							position++
+							goto l162
+						l164:
+							position, tokenIndex = position164, tokenIndex164
+						}
+						if !matchDot() {
+							goto l162
+						}
+						goto l161
+					l162:
+						position, tokenIndex = position162, tokenIndex162
+					}
+					add(rulePegText, position160)
+				}
+				if buffer[position] != rune('=') {
+					goto l158
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l158
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l158
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l158
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l158
+				}
+				position++
+				if !_rules[ruleend]() {
+					goto l158
+				}
+				add(ruleheading5, position159)
+			}
+			return true
+		l158:
+			position, tokenIndex = position158, tokenIndex158
+			return false
+		},
+		/* 15 heading6 <- <('=' '=' '=' '=' '=' '=' <(!('=' '=' '=' '=' '=' '=') .)+> ('=' '=' '=' '=' '=' '=') end)> */
+		func() bool {
+			position165, tokenIndex165 := position, tokenIndex
+			{
+				position166 := position
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				{
+					position167 := position
+					{
+						position170, tokenIndex170 := position, tokenIndex
+						if buffer[position] != rune('=') {
+							goto l170
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l170
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l170
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l170
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l170
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l170
+						}
+						position++
+						goto l165
+					l170:
+						position, tokenIndex = position170, tokenIndex170
+					}
+					if !matchDot() {
+						goto l165
+					}
+				l168:
+					{
+						position169, tokenIndex169 := position, tokenIndex
+						{
+							position171, tokenIndex171 := position, tokenIndex
+							if buffer[position] != rune('=') {
+								goto l171
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l171
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l171
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l171
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l171
+							}
+							position++
+							if buffer[position] != rune('=') {
+								goto l171
+							}
+							position++
+							goto l169
+						l171:
+							position, tokenIndex = position171, tokenIndex171
+						}
+						if !matchDot() {
+							goto l169
+						}
+						goto l168
+					l169:
+						position, tokenIndex = position169, tokenIndex169
+					}
+					add(rulePegText, position167)
+				}
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if buffer[position] != rune('=') {
+					goto l165
+				}
+				position++
+				if !_rules[ruleend]() {
+					goto l165
+				}
+				add(ruleheading6, position166)
+			}
+			return true
+		l165:
+			position, tokenIndex = position165, tokenIndex165
+			return false
+		},
+		/* 16 table <- <('{' '|' table_attributes (end table_line)* ((end (' ' / '\t')* ('|' '}')) / !.))> */
+		func() bool {
+			position172, tokenIndex172 := position, tokenIndex
+			{
+				position173 := position
+				if buffer[position] != rune('{') {
+					goto l172
+				}
+				position++
+				if buffer[position] != rune('|') {
+					goto l172
+				}
+				position++
+				if !_rules[ruletable_attributes]() {
+					goto l172
+				}
+			l174:
+				{
+					position175, tokenIndex175 := position, tokenIndex
+					if !_rules[ruleend]() {
+						goto l175
+					}
+					if !_rules[ruletable_line]() {
+						goto l175
+					}
+					goto l174
+				l175:
+					position, tokenIndex = position175, tokenIndex175
+				}
+				{
+					position176, tokenIndex176 := position, tokenIndex
+					if !_rules[ruleend]() {
+						goto l177
+					}
+				l178:
+					{
+						position179, tokenIndex179 := position, tokenIndex
+						{
+							position180, tokenIndex180 := position, tokenIndex
+							if buffer[position] != rune(' ') {
+								goto l181
+							}
+							position++
+							goto l180
+						l181:
+							position, tokenIndex = position180, tokenIndex180
+							if buffer[position] != rune('\t') {
+								goto l179
+							}
+							position++
+						}
+					l180:
+						goto l178
+					l179:
+						position, tokenIndex = position179, tokenIndex179
+					}
+					if buffer[position] != rune('|') {
+						goto l177
+					}
+					position++
+					if buffer[position] != rune('}') {
+						goto l177
+					}
+					position++
+					goto l176
+				l177:
+					position, tokenIndex = position176, tokenIndex176
+					{
+						position182, tokenIndex182 := position, tokenIndex
+						if !matchDot() {
+							goto l182
+						}
+						goto l172
+					l182:
+						position, tokenIndex = position182, tokenIndex182
+					}
+				}
+			l176:
+				add(ruletable, position173)
+			}
+			return true
+		l172:
+			position, tokenIndex = position172, tokenIndex172
+			return false
+		},
+		/* 17 table_attributes <- <(!end .)*> */
+		func() bool {
+			{
+				position184 := position
+			l185:
+				{
+					position186, tokenIndex186 := position, tokenIndex
+					{
+						position187, tokenIndex187 := position, tokenIndex
+						if !_rules[ruleend]() {
+							goto l187
+						}
+						goto l186
+					l187:
+						position, tokenIndex = position187, tokenIndex187
+					}
+					if !matchDot() {
+						goto l186
+					}
+					goto l185
+				l186:
+					position, tokenIndex = position186, tokenIndex186
+				}
+				add(ruletable_attributes, position184)
+			}
+			return true
+		},
+		/* 18 table_line <- <(((' ' / '\t')* (table_caption / table_row / table_headers / table_cells)) / ((' ' / '\t')* &end) / table_text)> */
+		func() bool {
+			position188, tokenIndex188 := position, tokenIndex
+			{
+				position189 := position
+				{
+					position190, tokenIndex190 := position, tokenIndex
+				l192:
+					{
+						position193, tokenIndex193 := position, tokenIndex
+						{
+							position194, tokenIndex194 := position, tokenIndex
+							if buffer[position] != rune(' ') {
+								goto l195
+							}
+							position++
+							goto l194
+						l195:
+							position, tokenIndex = position194, tokenIndex194
+							if buffer[position] != rune('\t') {
+								goto l193
+							}
+							position++
+						}
+					l194:
+						goto l192
+					l193:
+						position, tokenIndex = position193, tokenIndex193
+					}
+					{
+						position196, tokenIndex196 := position, tokenIndex
+						if !_rules[ruletable_caption]() {
+							goto l197
+						}
+						goto l196
+					l197:
+						position, tokenIndex = position196, tokenIndex196
+						if !_rules[ruletable_row]() {
+							goto l198
+						}
+						goto l196
+					l198:
+						position, tokenIndex = position196, tokenIndex196
+						if !_rules[ruletable_headers]() {
+							goto l199
+						}
+						goto l196
+					l199:
+						position, tokenIndex = position196, tokenIndex196
+						if !_rules[ruletable_cells]() {
+							goto l191
+						}
+					}
+				l196:
+					goto l190
+				l191:
+					position, tokenIndex = position190, tokenIndex190
+				l201:
+					{
+						position202, tokenIndex202 := position, tokenIndex
+						{
+							position203, tokenIndex203 := position, tokenIndex
+							if buffer[position] != rune(' ') {
+								goto l204
+							}
+							position++
+							goto l203
+						l204:
+							position, tokenIndex = position203, tokenIndex203
+							if buffer[position] != rune('\t') {
+								goto l202
+							}
+							position++
+						}
+					l203:
+						goto l201
+					l202:
+						position, tokenIndex = position202, tokenIndex202
+					}
+					{
+						position205, tokenIndex205 := position, tokenIndex
+						if !_rules[ruleend]() {
+							goto l200
+						}
+						position, tokenIndex = position205, tokenIndex205
+					}
+					goto l190
+				l200:
+					position, tokenIndex = position190, tokenIndex190
+					if !_rules[ruletable_text]() {
+						goto l188
+					}
+				}
+			l190:
+				add(ruletable_line, position189)
+			}
+			return true
+		l188:
+			position, tokenIndex = position188, tokenIndex188
+			return false
+		},
+		/* 19 table_text <- <(!((' ' / '\t')* ('|' '}')) (!end table_content)+)> */
+		func() bool {
+			position206, tokenIndex206 := position, tokenIndex
+			{
+				position207 := position
+				{
+					position208, tokenIndex208 := position, tokenIndex
+				l209:
+					{
+						position210, tokenIndex210 := position, tokenIndex
+						{
+							position211, tokenIndex211 := position, tokenIndex
+							if buffer[position] != rune(' ') {
+								goto l212
+							}
+							position++
+							goto l211
+						l212:
+							position, tokenIndex = position211, tokenIndex211
+							if buffer[position] != rune('\t') {
+								goto l210
+							}
+							position++
+						}
+					l211:
+						goto l209
+					l210:
+						position, tokenIndex = position210, tokenIndex210
+					}
+					if buffer[position] != rune('|') {
+						goto l208
+					}
+					position++
+					if buffer[position] != rune('}') {
+						goto l208
+					}
+					position++
+					goto l206
+				l208:
+					position, tokenIndex = position208, tokenIndex208
+				}
+				{
+					position215, tokenIndex215 := position, tokenIndex
+					if !_rules[ruleend]() {
+						goto l215
+					}
+					goto l206
+				l215:
+					position, tokenIndex = position215, tokenIndex215
+				}
+				if !_rules[ruletable_content]() {
+					goto l206
+				}
+			l213:
+				{
+					position214, tokenIndex214 := position, tokenIndex
+					{
+						position216, tokenIndex216 := position, tokenIndex
+						if !_rules[ruleend]() {
+							goto l216
+						}
+						goto l214
+					l216:
+						position, tokenIndex = position216, tokenIndex216
+					}
+					if !_rules[ruletable_content]() {
+						goto l214
+					}
+					goto l213
+				l214:
+					position, tokenIndex = position214, tokenIndex214
+				}
+				add(ruletable_text, position207)
+			}
+			return true
+		l206:
+			position, tokenIndex = position206, tokenIndex206
+			return false
+		},
+		/* 20 table_caption <- <('|' '+' (cell_attributes '|' !'|')? cell_content)> */
+		func() bool {
+			position217, tokenIndex217 := position, tokenIndex
+			{
+				position218 := position
+				if buffer[position] != rune('|') {
+					goto l217
+				}
+				position++
+				if buffer[position] != rune('+') {
+					goto l217
+				}
+				position++
+				{
+					position219, tokenIndex219 := position, tokenIndex
+					if !_rules[rulecell_attributes]() {
+						goto l219
+					}
+					if buffer[position] != rune('|') {
+						goto l219
+					}
+					position++
+					{
+						position221, tokenIndex221 := position, tokenIndex
+						if buffer[position] != rune('|') {
+							goto l221
+						}
+						position++
+						goto l219
+					l221:
+						position, tokenIndex = position221, tokenIndex221
+					}
+					goto l220
+				l219:
+					position, tokenIndex = position219, tokenIndex219
+				}
+			l220:
+				if !_rules[rulecell_content]() {
+					goto l217
+				}
+				add(ruletable_caption, position218)
+			}
+			return true
+		l217:
+			position, tokenIndex = position217, tokenIndex217
+			return false
+		},
+		/* 21 table_row <- <('|' '-' '-'* table_attributes)> */
+		func() bool {
+			position222, tokenIndex222 := position, tokenIndex
+			{
+				position223 := position
+				if buffer[position] != rune('|') {
+					goto l222
+				}
+				position++
+				if buffer[position] != rune('-') {
+					goto l222
+				}
+				position++
+			l224:
+				{
+					position225, tokenIndex225 := position, tokenIndex
+					if buffer[position] != rune('-') {
+						goto l225
+					}
+					position++
+					goto l224
+				l225:
+					position, tokenIndex = position225, tokenIndex225
+				}
+				if !_rules[ruletable_attributes]() {
+					goto l222
+				}
+				add(ruletable_row, position223)
+			}
+			return true
+		l222:
+			position, tokenIndex = position222, tokenIndex222
+			return false
+		},
+		/* 22 table_headers <- <('!' table_header ((('!' '!') / ('|' '|')) table_header)*)> */
+		func() bool {
+			position226, tokenIndex226 := position, tokenIndex
+			{
+				position227 := position
+				if buffer[position] != rune('!') {
+					goto l226
+				}
+				position++
+				if !_rules[ruletable_header]() {
+					goto l226
+				}
+			l228:
+				{
+					position229, tokenIndex229 := position, tokenIndex
+					{
+						position230, tokenIndex230 := position, tokenIndex
+						if buffer[position] != rune('!') {
+							goto l231
+						}
+						position++
+						if buffer[position] != rune('!') {
+							goto l231
+						}
+						position++
+						goto l230
+					l231:
+						position, tokenIndex = position230, tokenIndex230
+						if buffer[position] != rune('|') {
+							goto l229
+						}
+						position++
+						if buffer[position] != rune('|') {
+							goto l229
+						}
+						position++
+					}
+				l230:
+					if !_rules[ruletable_header]() {
+						goto l229
+					}
+					goto l228
+				l229:
+					position, tokenIndex = position229, tokenIndex229
+				}
+				add(ruletable_headers, position227)
+			}
+			return true
+		l226:
+			position, tokenIndex = position226, tokenIndex226
+			return false
+		},
+		/* 23 table_header <- <((cell_attributes '|' !'|')? header_content)> */
+		func() bool {
+			position232, tokenIndex232 := position, tokenIndex
+			{
+				position233 := position
+				{
+					position234, tokenIndex234 := position, tokenIndex
+					if !_rules[rulecell_attributes]() {
+						goto l234
+					}
+					if buffer[position] != rune('|') {
+						goto l234
+					}
+					position++
+					{
+						position236, tokenIndex236 := position, tokenIndex
+						if buffer[position] != rune('|') {
+							goto l236
+						}
+						position++
+						goto l234
+					l236:
+						position, tokenIndex = position236, tokenIndex236
+					}
+					goto l235
+				l234:
+					position, tokenIndex = position234, tokenIndex234
+				}
+			l235:
+				if !_rules[ruleheader_content]() {
+					goto l232
+				}
+				add(ruletable_header, position233)
+			}
+			return true
+		l232:
+			position, tokenIndex = position232, tokenIndex232
+			return false
+		},
+		/* 24 table_cells <- <('|' !('}' / '-' / '+') table_cell ('|' '|' table_cell)*)> */
+		func() bool {
+			position237, tokenIndex237 := position, tokenIndex
+			{
+				position238 := position
+				if buffer[position] != rune('|') {
+					goto l237
+				}
+				position++
+				{
+					position239, tokenIndex239 := position, tokenIndex
+					{
+						position240, tokenIndex240 := position, tokenIndex
+						if buffer[position] != rune('}') {
+							goto l241
+						}
+						position++
+						goto l240
+					l241:
+						position, tokenIndex = position240, tokenIndex240
+						if buffer[position] != rune('-') {
+							goto l242
+						}
+						position++
+						goto l240
+					l242:
+						position, tokenIndex = position240, tokenIndex240
+						if buffer[position] != rune('+') {
+							goto l239
+						}
+						position++
+					}
+				l240:
+					goto l237
+				l239:
+					position, tokenIndex = position239, tokenIndex239
+				}
+				if !_rules[ruletable_cell]() {
+					goto l237
+				}
+			l243:
+				{
+					position244, tokenIndex244 := position, tokenIndex
+					if buffer[position] != rune('|') {
+						goto l244
+					}
+					position++
+					if buffer[position] != rune('|') {
+						goto l244
+					}
+					position++
+					if !_rules[ruletable_cell]() {
+						goto l244
+					}
+					goto l243
+				l244:
+					position, tokenIndex = position244, tokenIndex244
+				}
+				add(ruletable_cells, position238)
+			}
+			return true
+		l237:
+			position, tokenIndex = position237, tokenIndex237
+			return false
+		},
+		/* 25 table_cell <- <((cell_attributes '|' !'|')? cell_content)> */
+		func() bool {
+			position245, tokenIndex245 := position, tokenIndex
+			{
+				position246 := position
+				{
+					position247, tokenIndex247 := position, tokenIndex
+					if !_rules[rulecell_attributes]() {
+						goto l247
+					}
+					if buffer[position] != rune('|') {
+						goto l247
+					}
+					position++
+					{
+						position249, tokenIndex249 := position, tokenIndex
+						if buffer[position] != rune('|') {
+							goto l249
 						}
 						position++
-						goto l84
-					l89:
-						position, tokenIndex = position89, tokenIndex89
-					}
-					if !matchDot() {
-						goto l84
+						goto l247
+					l249:
+						position, tokenIndex = position249, tokenIndex249
 					}
-				l87:
+					goto l248
+				l247:
+					position, tokenIndex = position247, tokenIndex247
+				}
+			l248:
+				if !_rules[rulecell_content]() {
+					goto l245
+				}
+				add(ruletable_cell, position246)
+			}
+			return true
+		l245:
+			position, tokenIndex = position245, tokenIndex245
+			return false
+		},
+		/* 26 cell_attributes <- <(!('|' / ('!' '!') / end / ('[' '[') / ('{' '{')) .)*> */
+		func() bool {
+			{
+				position251 := position
+			l252:
+				{
+					position253, tokenIndex253 := position, tokenIndex
 					{
-						position88, tokenIndex88 := position, tokenIndex
+						position254, tokenIndex254 := position, tokenIndex
 						{
-							position90, tokenIndex90 := position, tokenIndex
-							if buffer[position] != rune('=') {
-								goto l90
+							position255, tokenIndex255 := position, tokenIndex
+							if buffer[position] != rune('|') {
+								goto l256
 							}
 							position++
-							if buffer[position] != rune('=') {
-								goto l90
+							goto l255
+						l256:
+							position, tokenIndex = position255, tokenIndex255
+							if buffer[position] != rune('!') {
+								goto l257
 							}
 							position++
-							if buffer[position] != rune('=') {
-								goto l90
+							if buffer[position] != rune('!') {
+								goto l257
 							}
 							position++
-							if buffer[position] != rune('=') {
-								goto l90
+							goto l255
+						l257:
+							position, tokenIndex = position255, tokenIndex255
+							if !_rules[ruleend]() {
+								goto l258
+							}
+							goto l255
+						l258:
+							position, tokenIndex = position255, tokenIndex255
+							if buffer[position] != rune('[') {
+								goto l259
 							}
 							position++
-							if buffer[position] != rune('=') {
-								goto l90
+							if buffer[position] != rune('[') {
+								goto l259
+							}
+							position++
+							goto l255
+						l259:
+							position, tokenIndex = position255, tokenIndex255
+							if buffer[position] != rune('{') {
+								goto l254
+							}
+							position++
+							if buffer[position] != rune('{') {
+								goto l254
 							}
 							position++
-							goto l88
-						l90:
-							position, tokenIndex = position90, tokenIndex90
-						}
-						if !matchDot() {
-							goto l88
 						}
-						goto l87
-					l88:
-						position, tokenIndex = position88, tokenIndex88
+					l255:
+						goto l253
+					l254:
+						position, tokenIndex = position254, tokenIndex254
 					}
-					add(rulePegText, position86)
-				}
-				if buffer[position] != rune('=') {
-					goto l84
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l84
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l84
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l84
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l84
-				}
-				position++
-				if !_rules[ruleend]() {
-					goto l84
+					if !matchDot() {
+						goto l253
+					}
+					goto l252
+				l253:
+					position, tokenIndex = position253, tokenIndex253
 				}
-				add(ruleheading5, position85)
+				add(rulecell_attributes, position251)
 			}
 			return true
-		l84:
-			position, tokenIndex = position84, tokenIndex84
-			return false
 		},
-		/* 11 heading6 <- <('=' '=' '=' '=' '=' '=' <(!('=' '=' '=' '=' '=' '=') .)+> ('=' '=' '=' '=' '=' '=') end)> */
+		/* 27 cell_content <- <(!(('|' '|') / line_start) table_content)*> */
 		func() bool {
-			position91, tokenIndex91 := position, tokenIndex
 			{
-				position92 := position
-				if buffer[position] != rune('=') {
-					goto l91
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l91
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l91
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l91
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l91
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l91
-				}
-				position++
+				position261 := position
+			l262:
 				{
-					position93 := position
+					position263, tokenIndex263 := position, tokenIndex
 					{
-						position96, tokenIndex96 := position, tokenIndex
-						if buffer[position] != rune('=') {
-							goto l96
-						}
-						position++
-						if buffer[position] != rune('=') {
-							goto l96
-						}
-						position++
-						if buffer[position] != rune('=') {
-							goto l96
-						}
-						position++
-						if buffer[position] != rune('=') {
-							goto l96
-						}
-						position++
-						if buffer[position] != rune('=') {
-							goto l96
-						}
-						position++
-						if buffer[position] != rune('=') {
-							goto l96
+						position264, tokenIndex264 := position, tokenIndex
+						{
+							position265, tokenIndex265 := position, tokenIndex
+							if buffer[position] != rune('|') {
+								goto l266
+							}
+							position++
+							if buffer[position] != rune('|') {
+								goto l266
+							}
+							position++
+							goto l265
+						l266:
+							position, tokenIndex = position265, tokenIndex265
+							if !_rules[ruleline_start]() {
+								goto l264
+							}
 						}
-						position++
-						goto l91
-					l96:
-						position, tokenIndex = position96, tokenIndex96
+					l265:
+						goto l263
+					l264:
+						position, tokenIndex = position264, tokenIndex264
 					}
-					if !matchDot() {
-						goto l91
+					if !_rules[ruletable_content]() {
+						goto l263
 					}
-				l94:
+					goto l262
+				l263:
+					position, tokenIndex = position263, tokenIndex263
+				}
+				add(rulecell_content, position261)
+			}
+			return true
+		},
+		/* 28 header_content <- <(!(('|' '|') / ('!' '!') / line_start) table_content)*> */
+		func() bool {
+			{
+				position268 := position
+			l269:
+				{
+					position270, tokenIndex270 := position, tokenIndex
 					{
-						position95, tokenIndex95 := position, tokenIndex
+						position271, tokenIndex271 := position, tokenIndex
 						{
-							position97, tokenIndex97 := position, tokenIndex
-							if buffer[position] != rune('=') {
-								goto l97
-							}
-							position++
-							if buffer[position] != rune('=') {
-								goto l97
+							position272, tokenIndex272 := position, tokenIndex
+							if buffer[position] != rune('|') {
+								goto l273
 							}
 							position++
-							if buffer[position] != rune('=') {
-								goto l97
+							if buffer[position] != rune('|') {
+								goto l273
 							}
 							position++
-							if buffer[position] != rune('=') {
-								goto l97
+							goto l272
+						l273:
+							position, tokenIndex = position272, tokenIndex272
+							if buffer[position] != rune('!') {
+								goto l274
 							}
 							position++
-							if buffer[position] != rune('=') {
-								goto l97
+							if buffer[position] != rune('!') {
+								goto l274
 							}
 							position++
-							if buffer[position] != rune('=') {
-								goto l97
+							goto l272
+						l274:
+							position, tokenIndex = position272, tokenIndex272
+							if !_rules[ruleline_start]() {
+								goto l271
 							}
-							position++
-							goto l95
-						l97:
-							position, tokenIndex = position97, tokenIndex97
 						}
-						if !matchDot() {
-							goto l95
-						}
-						goto l94
-					l95:
-						position, tokenIndex = position95, tokenIndex95
+					l272:
+						goto l270
+					l271:
+						position, tokenIndex = position271, tokenIndex271
 					}
-					add(rulePegText, position93)
-				}
-				if buffer[position] != rune('=') {
-					goto l91
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l91
-				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l91
+					if !_rules[ruletable_content]() {
+						goto l270
+					}
+					goto l269
+				l270:
+					position, tokenIndex = position270, tokenIndex270
 				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l91
+				add(ruleheader_content, position268)
+			}
+			return true
+		},
+		/* 29 line_start <- <(end (' ' / '\t')* ('|' / '!'))> */
+		func() bool {
+			position275, tokenIndex275 := position, tokenIndex
+			{
+				position276 := position
+				if !_rules[ruleend]() {
+					goto l275
 				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l91
+			l277:
+				{
+					position278, tokenIndex278 := position, tokenIndex
+					{
+						position279, tokenIndex279 := position, tokenIndex
+						if buffer[position] != rune(' ') {
+							goto l280
+						}
+						position++
+						goto l279
+					l280:
+						position, tokenIndex = position279, tokenIndex279
+						if buffer[position] != rune('\t') {
+							goto l278
+						}
+						position++
+					}
+				l279:
+					goto l277
+				l278:
+					position, tokenIndex = position278, tokenIndex278
 				}
-				position++
-				if buffer[position] != rune('=') {
-					goto l91
+				{
+					position281, tokenIndex281 := position, tokenIndex
+					if buffer[position] != rune('|') {
+						goto l282
+					}
+					position++
+					goto l281
+				l282:
+					position, tokenIndex = position281, tokenIndex281
+					if buffer[position] != rune('!') {
+						goto l275
+					}
+					position++
 				}
-				position++
-				if !_rules[ruleend]() {
-					goto l91
+			l281:
+				add(ruleline_start, position276)
+			}
+			return true
+		l275:
+			position, tokenIndex = position275, tokenIndex275
+			return false
+		},
+		/* 30 table_content <- <(table / free / cite / quote / format / wild)> */
+		func() bool {
+			position283, tokenIndex283 := position, tokenIndex
+			{
+				position284 := position
+				{
+					position285, tokenIndex285 := position, tokenIndex
+					if !_rules[ruletable]() {
+						goto l286
+					}
+					goto l285
+				l286:
+					position, tokenIndex = position285, tokenIndex285
+					if !_rules[rulefree]() {
+						goto l287
+					}
+					goto l285
+				l287:
+					position, tokenIndex = position285, tokenIndex285
+					if !_rules[rulecite]() {
+						goto l288
+					}
+					goto l285
+				l288:
+					position, tokenIndex = position285, tokenIndex285
+					if !_rules[rulequote]() {
+						goto l289
+					}
+					goto l285
+				l289:
+					position, tokenIndex = position285, tokenIndex285
+					if !_rules[ruleformat]() {
+						goto l290
+					}
+					goto l285
+				l290:
+					position, tokenIndex = position285, tokenIndex285
+					if !_rules[rulewild]() {
+						goto l283
+					}
 				}
-				add(ruleheading6, position92)
+			l285:
+				add(ruletable_content, position284)
 			}
 			return true
-		l91:
-			position, tokenIndex = position91, tokenIndex91
+		l283:
+			position, tokenIndex = position283, tokenIndex283
 			return false
 		},
-		/* 12 hr <- <('-' '-' '-' '-' end)> */
+		/* 31 hr <- <('-' '-' '-' '-' end)> */
 		func() bool {
-			position98, tokenIndex98 := position, tokenIndex
+			position291, tokenIndex291 := position, tokenIndex
 			{
-				position99 := position
+				position292 := position
 				if buffer[position] != rune('-') {
-					goto l98
+					goto l291
 				}
 				position++
 				if buffer[position] != rune('-') {
-					goto l98
+					goto l291
 				}
 				position++
 				if buffer[position] != rune('-') {
-					goto l98
+					goto l291
 				}
 				position++
 				if buffer[position] != rune('-') {
-					goto l98
+					goto l291
 				}
 				position++
 				if !_rules[ruleend]() {
-					goto l98
+					goto l291
 				}
-				add(rulehr, position99)
+				add(rulehr, position292)
 			}
 			return true
-		l98:
-			position, tokenIndex = position98, tokenIndex98
+		l291:
+			position, tokenIndex = position291, tokenIndex291
 			return false
 		},
-		/* 13 br <- <(end end)> */
+		/* 32 br <- <(end end)> */
 		func() bool {
-			position100, tokenIndex100 := position, tokenIndex
+			position293, tokenIndex293 := position, tokenIndex
 			{
-				position101 := position
+				position294 := position
 				if !_rules[ruleend]() {
-					goto l100
+					goto l293
 				}
 				if !_rules[ruleend]() {
-					goto l100
+					goto l293
 				}
-				add(rulebr, position101)
+				add(rulebr, position294)
 			}
 			return true
-		l100:
-			position, tokenIndex = position100, tokenIndex100
+		l293:
+			position, tokenIndex = position293, tokenIndex293
 			return false
 		},
-		/* 14 list_content <- <(free / wild)> */
+		/* 33 list_content <- <(free / quote / format / wild)> */
 		func() bool {
-			position102, tokenIndex102 := position, tokenIndex
+			position295, tokenIndex295 := position, tokenIndex
 			{
-				position103 := position
+				position296 := position
 				{
-					position104, tokenIndex104 := position, tokenIndex
+					position297, tokenIndex297 := position, tokenIndex
 					if !_rules[rulefree]() {
-						goto l105
-					}
-					goto l104
-				l105:
-					position, tokenIndex = position104, tokenIndex104
+						goto l298
+					}
+					goto l297
+				l298:
+					position, tokenIndex = position297, tokenIndex297
+					if !_rules[rulequote]() {
+						goto l299
+					}
+					goto l297
+				l299:
+					position, tokenIndex = position297, tokenIndex297
+					if !_rules[ruleformat]() {
+						goto l300
+					}
+					goto l297
+				l300:
+					position, tokenIndex = position297, tokenIndex297
 					if !_rules[rulewild]() {
-						goto l102
+						goto l295
 					}
 				}
-			l104:
-				add(rulelist_content, position103)
+			l297:
+				add(rulelist_content, position296)
 			}
 			return true
-		l102:
-			position, tokenIndex = position102, tokenIndex102
+		l295:
+			position, tokenIndex = position295, tokenIndex295
 			return false
 		},
-		/* 15 list <- <(ulist4 / olist4 / ulist3 / olist3 / ulist2 / olist2 / ulist1 / olist1)+> */
+		/* 34 list <- <(ulist4 / olist4 / ulist3 / olist3 / ulist2 / olist2 / ulist1 / olist1)+> */
 		func() bool {
-			position106, tokenIndex106 := position, tokenIndex
+			position301, tokenIndex301 := position, tokenIndex
 			{
-				position107 := position
+				position302 := position
 				{
-					position110, tokenIndex110 := position, tokenIndex
+					position305, tokenIndex305 := position, tokenIndex
 					if !_rules[ruleulist4]() {
-						goto l111
+						goto l306
 					}
-					goto l110
-				l111:
-					position, tokenIndex = position110, tokenIndex110
+					goto l305
+				l306:
+					position, tokenIndex = position305, tokenIndex305
 					if !_rules[ruleolist4]() {
-						goto l112
+						goto l307
 					}
-					goto l110
-				l112:
-					position, tokenIndex = position110, tokenIndex110
+					goto l305
+				l307:
+					position, tokenIndex = position305, tokenIndex305
 					if !_rules[ruleulist3]() {
-						goto l113
+						goto l308
 					}
-					goto l110
-				l113:
-					position, tokenIndex = position110, tokenIndex110
+					goto l305
+				l308:
+					position, tokenIndex = position305, tokenIndex305
 					if !_rules[ruleolist3]() {
-						goto l114
+						goto l309
 					}
-					goto l110
-				l114:
-					position, tokenIndex = position110, tokenIndex110
+					goto l305
+				l309:
+					position, tokenIndex = position305, tokenIndex305
 					if !_rules[ruleulist2]() {
-						goto l115
+						goto l310
 					}
-					goto l110
-				l115:
-					position, tokenIndex = position110, tokenIndex110
+					goto l305
+				l310:
+					position, tokenIndex = position305, tokenIndex305
 					if !_rules[ruleolist2]() {
-						goto l116
+						goto l311
 					}
-					goto l110
-				l116:
-					position, tokenIndex = position110, tokenIndex110
+					goto l305
+				l311:
+					position, tokenIndex = position305, tokenIndex305
 					if !_rules[ruleulist1]() {
-						goto l117
+						goto l312
 					}
-					goto l110
-				l117:
-					position, tokenIndex = position110, tokenIndex110
+					goto l305
+				l312:
+					position, tokenIndex = position305, tokenIndex305
 					if !_rules[ruleolist1]() {
-						goto l106
+						goto l301
 					}
 				}
-			l110:
-			l108:
+			l305:
+			l303:
 				{
-					position109, tokenIndex109 := position, tokenIndex
+					position304, tokenIndex304 := position, tokenIndex
 					{
-						position118, tokenIndex118 := position, tokenIndex
+						position313, tokenIndex313 := position, tokenIndex
 						if !_rules[ruleulist4]() {
-							goto l119
+							goto l314
 						}
-						goto l118
-					l119:
-						position, tokenIndex = position118, tokenIndex118
+						goto l313
+					l314:
+						position, tokenIndex = position313, tokenIndex313
 						if !_rules[ruleolist4]() {
-							goto l120
+							goto l315
 						}
-						goto l118
-					l120:
-						position, tokenIndex = position118, tokenIndex118
+						goto l313
+					l315:
+						position, tokenIndex = position313, tokenIndex313
 						if !_rules[ruleulist3]() {
-							goto l121
+							goto l316
 						}
-						goto l118
-					l121:
-						position, tokenIndex = position118, tokenIndex118
+						goto l313
+					l316:
+						position, tokenIndex = position313, tokenIndex313
 						if !_rules[ruleolist3]() {
-							goto l122
+							goto l317
 						}
-						goto l118
-					l122:
-						position, tokenIndex = position118, tokenIndex118
+						goto l313
+					l317:
+						position, tokenIndex = position313, tokenIndex313
 						if !_rules[ruleulist2]() {
-							goto l123
+							goto l318
 						}
-						goto l118
-					l123:
-						position, tokenIndex = position118, tokenIndex118
+						goto l313
+					l318:
+						position, tokenIndex = position313, tokenIndex313
 						if !_rules[ruleolist2]() {
-							goto l124
+							goto l319
 						}
-						goto l118
-					l124:
-						position, tokenIndex = position118, tokenIndex118
+						goto l313
+					l319:
+						position, tokenIndex = position313, tokenIndex313
 						if !_rules[ruleulist1]() {
-							goto l125
+							goto l320
 						}
-						goto l118
-					l125:
-						position, tokenIndex = position118, tokenIndex118
+						goto l313
+					l320:
+						position, tokenIndex = position313, tokenIndex313
 						if !_rules[ruleolist1]() {
-							goto l109
+							goto l304
 						}
 					}
-				l118:
-					goto l108
-				l109:
-					position, tokenIndex = position109, tokenIndex109
+				l313:
+					goto l303
+				l304:
+					position, tokenIndex = position304, tokenIndex304
 				}
-				add(rulelist, position107)
+				add(rulelist, position302)
 			}
 			return true
-		l106:
-			position, tokenIndex = position106, tokenIndex106
+		l301:
+			position, tokenIndex = position301, tokenIndex301
 			return false
 		},
-		/* 16 l <- <('*' / '#')> */
+		/* 35 l <- <('*' / '#')> */
 		func() bool {
-			position126, tokenIndex126 := position, tokenIndex
+			position321, tokenIndex321 := position, tokenIndex
 			{
-				position127 := position
+				position322 := position
 				{
-					position128, tokenIndex128 := position, tokenIndex
+					position323, tokenIndex323 := position, tokenIndex
 					if buffer[position] != rune('*') {
-						goto l129
+						goto l324
 					}
 					position++
-					goto l128
-				l129:
-					position, tokenIndex = position128, tokenIndex128
+					goto l323
+				l324:
+					position, tokenIndex = position323, tokenIndex323
 					if buffer[position] != rune('#') {
-						goto l126
+						goto l321
 					}
 					position++
 				}
-			l128:
-				add(rulel, position127)
+			l323:
+				add(rulel, position322)
 			}
 			return true
-		l126:
-			position, tokenIndex = position126, tokenIndex126
+		l321:
+			position, tokenIndex = position321, tokenIndex321
 			return false
 		},
-		/* 17 ulist1 <- <('*' ' ' (!end list_content)* end)> */
+		/* 36 ulist1 <- <('*' ' ' (!end list_content)* end)> */
 		func() bool {
-			position130, tokenIndex130 := position, tokenIndex
+			position325, tokenIndex325 := position, tokenIndex
 			{
-				position131 := position
+				position326 := position
 				if buffer[position] != rune('*') {
-					goto l130
+					goto l325
 				}
 				position++
 				if buffer[position] != rune(' ') {
-					goto l130
+					goto l325
 				}
 				position++
-			l132:
+			l327:
 				{
-					position133, tokenIndex133 := position, tokenIndex
+					position328, tokenIndex328 := position, tokenIndex
 					{
-						position134, tokenIndex134 := position, tokenIndex
+						position329, tokenIndex329 := position, tokenIndex
 						if !_rules[ruleend]() {
-							goto l134
+							goto l329
 						}
-						goto l133
-					l134:
-						position, tokenIndex = position134, tokenIndex134
+						goto l328
+					l329:
+						position, tokenIndex = position329, tokenIndex329
 					}
 					if !_rules[rulelist_content]() {
-						goto l133
+						goto l328
 					}
-					goto l132
-				l133:
-					position, tokenIndex = position133, tokenIndex133
+					goto l327
+				l328:
+					position, tokenIndex = position328, tokenIndex328
 				}
 				if !_rules[ruleend]() {
-					goto l130
+					goto l325
 				}
-				add(ruleulist1, position131)
+				add(ruleulist1, position326)
 			}
 			return true
-		l130:
-			position, tokenIndex = position130, tokenIndex130
+		l325:
+			position, tokenIndex = position325, tokenIndex325
 			return false
 		},
-		/* 18 ulist2 <- <(l ('*' ' ') (!end list_content)* end)> */
+		/* 37 ulist2 <- <(l ('*' ' ') (!end list_content)* end)> */
 		func() bool {
-			position135, tokenIndex135 := position, tokenIndex
+			position330, tokenIndex330 := position, tokenIndex
 			{
-				position136 := position
+				position331 := position
 				if !_rules[rulel]() {
-					goto l135
+					goto l330
 				}
 				if buffer[position] != rune('*') {
-					goto l135
+					goto l330
 				}
 				position++
 				if buffer[position] != rune(' ') {
-					goto l135
+					goto l330
 				}
 				position++
-			l137:
+			l332:
 				{
-					position138, tokenIndex138 := position, tokenIndex
+					position333, tokenIndex333 := position, tokenIndex
 					{
-						position139, tokenIndex139 := position, tokenIndex
+						position334, tokenIndex334 := position, tokenIndex
 						if !_rules[ruleend]() {
-							goto l139
+							goto l334
 						}
-						goto l138
-					l139:
-						position, tokenIndex = position139, tokenIndex139
+						goto l333
+					l334:
+						position, tokenIndex = position334, tokenIndex334
 					}
 					if !_rules[rulelist_content]() {
-						goto l138
+						goto l333
 					}
-					goto l137
-				l138:
-					position, tokenIndex = position138, tokenIndex138
+					goto l332
+				l333:
+					position, tokenIndex = position333, tokenIndex333
 				}
 				if !_rules[ruleend]() {
-					goto l135
+					goto l330
 				}
-				add(ruleulist2, position136)
+				add(ruleulist2, position331)
 			}
 			return true
-		l135:
-			position, tokenIndex = position135, tokenIndex135
+		l330:
+			position, tokenIndex = position330, tokenIndex330
 			return false
 		},
-		/* 19 ulist3 <- <(l l ('*' ' ') (!end list_content)* end)> */
+		/* 38 ulist3 <- <(l l ('*' ' ') (!end list_content)* end)> */
 		func() bool {
-			position140, tokenIndex140 := position, tokenIndex
+			position335, tokenIndex335 := position, tokenIndex
 			{
-				position141 := position
+				position336 := position
 				if !_rules[rulel]() {
-					goto l140
+					goto l335
 				}
 				if !_rules[rulel]() {
-					goto l140
+					goto l335
 				}
 				if buffer[position] != rune('*') {
-					goto l140
+					goto l335
 				}
 				position++
 				if buffer[position] != rune(' ') {
-					goto l140
+					goto l335
 				}
 				position++
-			l142:
+			l337:
 				{
-					position143, tokenIndex143 := position, tokenIndex
+					position338, tokenIndex338 := position, tokenIndex
 					{
-						position144, tokenIndex144 := position, tokenIndex
+						position339, tokenIndex339 := position, tokenIndex
 						if !_rules[ruleend]() {
-							goto l144
+							goto l339
 						}
-						goto l143
-					l144:
-						position, tokenIndex = position144, tokenIndex144
+						goto l338
+					l339:
+						position, tokenIndex = position339, tokenIndex339
 					}
 					if !_rules[rulelist_content]() {
-						goto l143
+						goto l338
 					}
-					goto l142
-				l143:
-					position, tokenIndex = position143, tokenIndex143
+					goto l337
+				l338:
+					position, tokenIndex = position338, tokenIndex338
 				}
 				if !_rules[ruleend]() {
-					goto l140
+					goto l335
 				}
-				add(ruleulist3, position141)
+				add(ruleulist3, position336)
 			}
 			return true
-		l140:
-			position, tokenIndex = position140, tokenIndex140
+		l335:
+			position, tokenIndex = position335, tokenIndex335
 			return false
 		},
-		/* 20 ulist4 <- <(l l l ('*' ' ') (!end list_content)* end)> */
+		/* 39 ulist4 <- <(l l l ('*' ' ') (!end list_content)* end)> */
 		func() bool {
-			position145, tokenIndex145 := position, tokenIndex
+			position340, tokenIndex340 := position, tokenIndex
 			{
-				position146 := position
+				position341 := position
 				if !_rules[rulel]() {
-					goto l145
+					goto l340
 				}
 				if !_rules[rulel]() {
-					goto l145
+					goto l340
 				}
 				if !_rules[rulel]() {
-					goto l145
+					goto l340
 				}
 				if buffer[position] != rune('*') {
-					goto l145
+					goto l340
 				}
 				position++
 				if buffer[position] != rune(' ') {
-					goto l145
+					goto l340
 				}
 				position++
-			l147:
+			l342:
 				{
-					position148, tokenIndex148 := position, tokenIndex
+					position343, tokenIndex343 := position, tokenIndex
 					{
-						position149, tokenIndex149 := position, tokenIndex
+						position344, tokenIndex344 := position, tokenIndex
 						if !_rules[ruleend]() {
-							goto l149
+							goto l344
 						}
-						goto l148
-					l149:
-						position, tokenIndex = position149, tokenIndex149
+						goto l343
+					l344:
+						position, tokenIndex = position344, tokenIndex344
 					}
 					if !_rules[rulelist_content]() {
-						goto l148
+						goto l343
 					}
-					goto l147
-				l148:
-					position, tokenIndex = position148, tokenIndex148
+					goto l342
+				l343:
+					position, tokenIndex = position343, tokenIndex343
 				}
 				if !_rules[ruleend]() {
-					goto l145
+					goto l340
 				}
-				add(ruleulist4, position146)
+				add(ruleulist4, position341)
 			}
 			return true
-		l145:
-			position, tokenIndex = position145, tokenIndex145
+		l340:
+			position, tokenIndex = position340, tokenIndex340
 			return false
 		},
-		/* 21 olist1 <- <('#' ' ' (!end list_content)* end)> */
+		/* 40 olist1 <- <('#' ' ' (!end list_content)* end)> */
 		func() bool {
-			position150, tokenIndex150 := position, tokenIndex
+			position345, tokenIndex345 := position, tokenIndex
 			{
-				position151 := position
+				position346 := position
 				if buffer[position] != rune('#') {
-					goto l150
+					goto l345
 				}
 				position++
 				if buffer[position] != rune(' ') {
-					goto l150
+					goto l345
 				}
 				position++
-			l152:
+			l347:
 				{
-					position153, tokenIndex153 := position, tokenIndex
+					position348, tokenIndex348 := position, tokenIndex
 					{
-						position154, tokenIndex154 := position, tokenIndex
+						position349, tokenIndex349 := position, tokenIndex
 						if !_rules[ruleend]() {
-							goto l154
+							goto l349
 						}
-						goto l153
-					l154:
-						position, tokenIndex = position154, tokenIndex154
+						goto l348
+					l349:
+						position, tokenIndex = position349, tokenIndex349
 					}
 					if !_rules[rulelist_content]() {
-						goto l153
+						goto l348
 					}
-					goto l152
-				l153:
-					position, tokenIndex = position153, tokenIndex153
+					goto l347
+				l348:
+					position, tokenIndex = position348, tokenIndex348
 				}
 				if !_rules[ruleend]() {
-					goto l150
+					goto l345
 				}
-				add(ruleolist1, position151)
+				add(ruleolist1, position346)
 			}
 			return true
-		l150:
-			position, tokenIndex = position150, tokenIndex150
+		l345:
+			position, tokenIndex = position345, tokenIndex345
 			return false
 		},
-		/* 22 olist2 <- <(l ('#' ' ') (!end list_content)* end)> */
+		/* 41 olist2 <- <(l ('#' ' ') (!end list_content)* end)> */
 		func() bool {
-			position155, tokenIndex155 := position, tokenIndex
+			position350, tokenIndex350 := position, tokenIndex
 			{
-				position156 := position
+				position351 := position
 				if !_rules[rulel]() {
-					goto l155
+					goto l350
 				}
 				if buffer[position] != rune('#') {
-					goto l155
+					goto l350
 				}
 				position++
 				if buffer[position] != rune(' ') {
-					goto l155
+					goto l350
 				}
 				position++
-			l157:
+			l352:
 				{
-					position158, tokenIndex158 := position, tokenIndex
+					position353, tokenIndex353 := position, tokenIndex
 					{
-						position159, tokenIndex159 := position, tokenIndex
+						position354, tokenIndex354 := position, tokenIndex
 						if !_rules[ruleend]() {
-							goto l159
+							goto l354
 						}
-						goto l158
-					l159:
-						position, tokenIndex = position159, tokenIndex159
+						goto l353
+					l354:
+						position, tokenIndex = position354, tokenIndex354
 					}
 					if !_rules[rulelist_content]() {
-						goto l158
+						goto l353
 					}
-					goto l157
-				l158:
-					position, tokenIndex = position158, tokenIndex158
+					goto l352
+				l353:
+					position, tokenIndex = position353, tokenIndex353
 				}
 				if !_rules[ruleend]() {
-					goto l155
+					goto l350
 				}
-				add(ruleolist2, position156)
+				add(ruleolist2, position351)
 			}
 			return true
-		l155:
-			position, tokenIndex = position155, tokenIndex155
+		l350:
+			position, tokenIndex = position350, tokenIndex350
 			return false
 		},
-		/* 23 olist3 <- <(l l ('#' ' ') (!end list_content)* end)> */
+		/* 42 olist3 <- <(l l ('#' ' ') (!end list_content)* end)> */
 		func() bool {
-			position160, tokenIndex160 := position, tokenIndex
+			position355, tokenIndex355 := position, tokenIndex
 			{
-				position161 := position
+				position356 := position
 				if !_rules[rulel]() {
-					goto l160
+					goto l355
 				}
 				if !_rules[rulel]() {
-					goto l160
+					goto l355
 				}
 				if buffer[position] != rune('#') {
-					goto l160
+					goto l355
 				}
 				position++
 				if buffer[position] != rune(' ') {
-					goto l160
+					goto l355
 				}
 				position++
-			l162:
+			l357:
 				{
-					position163, tokenIndex163 := position, tokenIndex
+					position358, tokenIndex358 := position, tokenIndex
 					{
-						position164, tokenIndex164 := position, tokenIndex
+						position359, tokenIndex359 := position, tokenIndex
 						if !_rules[ruleend]() {
-							goto l164
+							goto l359
 						}
-						goto l163
-					l164:
-						position, tokenIndex = position164, tokenIndex164
+						goto l358
+					l359:
+						position, tokenIndex = position359, tokenIndex359
 					}
 					if !_rules[rulelist_content]() {
-						goto l163
+						goto l358
 					}
-					goto l162
-				l163:
-					position, tokenIndex = position163, tokenIndex163
+					goto l357
+				l358:
+					position, tokenIndex = position358, tokenIndex358
 				}
 				if !_rules[ruleend]() {
-					goto l160
+					goto l355
 				}
-				add(ruleolist3, position161)
+				add(ruleolist3, position356)
 			}
 			return true
-		l160:
-			position, tokenIndex = position160, tokenIndex160
+		l355:
+			position, tokenIndex = position355, tokenIndex355
 			return false
 		},
-		/* 24 olist4 <- <(l l l ('#' ' ') (!end list_content)* end)> */
+		/* 43 olist4 <- <(l l l ('#' ' ') (!end list_content)* end)> */
 		func() bool {
-			position165, tokenIndex165 := position, tokenIndex
+			position360, tokenIndex360 := position, tokenIndex
 			{
-				position166 := position
+				position361 := position
 				if !_rules[rulel]() {
-					goto l165
+					goto l360
 				}
 				if !_rules[rulel]() {
-					goto l165
+					goto l360
 				}
 				if !_rules[rulel]() {
-					goto l165
+					goto l360
 				}
 				if buffer[position] != rune('#') {
-					goto l165
+					goto l360
 				}
 				position++
 				if buffer[position] != rune(' ') {
-					goto l165
+					goto l360
 				}
 				position++
-			l167:
+			l362:
 				{
-					position168, tokenIndex168 := position, tokenIndex
+					position363, tokenIndex363 := position, tokenIndex
 					{
-						position169, tokenIndex169 := position, tokenIndex
+						position364, tokenIndex364 := position, tokenIndex
 						if !_rules[ruleend]() {
-							goto l169
+							goto l364
 						}
-						goto l168
-					l169:
-						position, tokenIndex = position169, tokenIndex169
+						goto l363
+					l364:
+						position, tokenIndex = position364, tokenIndex364
 					}
 					if !_rules[rulelist_content]() {
-						goto l168
+						goto l363
 					}
-					goto l167
-				l168:
-					position, tokenIndex = position168, tokenIndex168
+					goto l362
+				l363:
+					position, tokenIndex = position363, tokenIndex363
 				}
 				if !_rules[ruleend]() {
-					goto l165
+					goto l360
 				}
-				add(ruleolist4, position166)
+				add(ruleolist4, position361)
 			}
 			return true
-		l165:
-			position, tokenIndex = position165, tokenIndex165
+		l360:
+			position, tokenIndex = position360, tokenIndex360
 			return false
 		},
-		/* 25 end <- <('\n' / ('\r' '\n'))> */
+		/* 44 end <- <('\n' / ('\r' '\n'))> */
 		func() bool {
-			position170, tokenIndex170 := position, tokenIndex
+			position365, tokenIndex365 := position, tokenIndex
 			{
-				position171 := position
+				position366 := position
 				{
-					position172, tokenIndex172 := position, tokenIndex
+					position367, tokenIndex367 := position, tokenIndex
 					if buffer[position] != rune('\n') {
-						goto l173
+						goto l368
 					}
 					position++
-					goto l172
-				l173:
-					position, tokenIndex = position172, tokenIndex172
+					goto l367
+				l368:
+					position, tokenIndex = position367, tokenIndex367
 					if buffer[position] != rune('\r') {
-						goto l170
+						goto l365
 					}
 					position++
 					if buffer[position] != rune('\n') {
-						goto l170
+						goto l365
 					}
 					position++
 				}
-			l172:
-				add(ruleend, position171)
+			l367:
+				add(ruleend, position366)
 			}
 			return true
-		l170:
-			position, tokenIndex = position170, tokenIndex170
+		l365:
+			position, tokenIndex = position365, tokenIndex365
 			return false
 		},
-		/* 26 wild <- <.> */
+		/* 45 wild <- <.> */
 		func() bool {
-			position174, tokenIndex174 := position, tokenIndex
+			position369, tokenIndex369 := position, tokenIndex
 			{
-				position175 := position
+				position370 := position
 				if !matchDot() {
-					goto l174
+					goto l369
 				}
-				add(rulewild, position175)
+				add(rulewild, position370)
 			}
 			return true
-		l174:
-			position, tokenIndex = position174, tokenIndex174
+		l369:
+			position, tokenIndex = position369, tokenIndex369
 			return false
 		},
 		nil,
@@ -0,0 +1,245 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package wikipedia
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// EncyclopediaClient is the client API for Encyclopedia service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EncyclopediaClient interface {
+	// Lookup looks up an article, redirects are followed
+	Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*Article, error)
+	// Search streams the summary and then a page of the results of a query
+	Search(ctx context.Context, in *SearchQuery, opts ...grpc.CallOption) (Encyclopedia_SearchClient, error)
+	// Complete completes the prefix of a title
+	Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*Completions, error)
+	// Backlinks returns the articles that link to an article
+	Backlinks(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*Backlinks, error)
+}
+
+type encyclopediaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEncyclopediaClient(cc grpc.ClientConnInterface) EncyclopediaClient {
+	return &encyclopediaClient{cc}
+}
+
+func (c *encyclopediaClient) Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*Article, error) {
+	out := new(Article)
+	err := c.cc.Invoke(ctx, "/wikipedia.Encyclopedia/Lookup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *encyclopediaClient) Search(ctx context.Context, in *SearchQuery, opts ...grpc.CallOption) (Encyclopedia_SearchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Encyclopedia_ServiceDesc.Streams[0], "/wikipedia.Encyclopedia/Search", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &encyclopediaSearchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Encyclopedia_SearchClient interface {
+	Recv() (*SearchResponse, error)
+	grpc.ClientStream
+}
+
+type encyclopediaSearchClient struct {
+	grpc.ClientStream
+}
+
+func (x *encyclopediaSearchClient) Recv() (*SearchResponse, error) {
+	m := new(SearchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *encyclopediaClient) Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*Completions, error) {
+	out := new(Completions)
+	err := c.cc.Invoke(ctx, "/wikipedia.Encyclopedia/Complete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *encyclopediaClient) Backlinks(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*Backlinks, error) {
+	out := new(Backlinks)
+	err := c.cc.Invoke(ctx, "/wikipedia.Encyclopedia/Backlinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EncyclopediaServer is the server API for Encyclopedia service.
+// All implementations must embed UnimplementedEncyclopediaServer
+// for forward compatibility
+type EncyclopediaServer interface {
+	// Lookup looks up an article, redirects are followed
+	Lookup(context.Context, *LookupRequest) (*Article, error)
+	// Search streams the summary and then a page of the results of a query
+	Search(*SearchQuery, Encyclopedia_SearchServer) error
+	// Complete completes the prefix of a title
+	Complete(context.Context, *CompleteRequest) (*Completions, error)
+	// Backlinks returns the articles that link to an article
+	Backlinks(context.Context, *LookupRequest) (*Backlinks, error)
+	mustEmbedUnimplementedEncyclopediaServer()
+}
+
+// UnimplementedEncyclopediaServer must be embedded to have forward compatible implementations.
+type UnimplementedEncyclopediaServer struct {
+}
+
+func (UnimplementedEncyclopediaServer) Lookup(context.Context, *LookupRequest) (*Article, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lookup not implemented")
+}
+func (UnimplementedEncyclopediaServer) Search(*SearchQuery, Encyclopedia_SearchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedEncyclopediaServer) Complete(context.Context, *CompleteRequest) (*Completions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Complete not implemented")
+}
+func (UnimplementedEncyclopediaServer) Backlinks(context.Context, *LookupRequest) (*Backlinks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Backlinks not implemented")
+}
+func (UnimplementedEncyclopediaServer) mustEmbedUnimplementedEncyclopediaServer() {}
+
+// UnsafeEncyclopediaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EncyclopediaServer will
+// result in compilation errors.
+type UnsafeEncyclopediaServer interface {
+	mustEmbedUnimplementedEncyclopediaServer()
+}
+
+func RegisterEncyclopediaServer(s grpc.ServiceRegistrar, srv EncyclopediaServer) {
+	s.RegisterService(&Encyclopedia_ServiceDesc, srv)
+}
+
+func _Encyclopedia_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncyclopediaServer).Lookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikipedia.Encyclopedia/Lookup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncyclopediaServer).Lookup(ctx, req.(*LookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Encyclopedia_Search_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EncyclopediaServer).Search(m, &encyclopediaSearchServer{stream})
+}
+
+type Encyclopedia_SearchServer interface {
+	Send(*SearchResponse) error
+	grpc.ServerStream
+}
+
+type encyclopediaSearchServer struct {
+	grpc.ServerStream
+}
+
+func (x *encyclopediaSearchServer) Send(m *SearchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Encyclopedia_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncyclopediaServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikipedia.Encyclopedia/Complete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncyclopediaServer).Complete(ctx, req.(*CompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Encyclopedia_Backlinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EncyclopediaServer).Backlinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wikipedia.Encyclopedia/Backlinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EncyclopediaServer).Backlinks(ctx, req.(*LookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Encyclopedia_ServiceDesc is the grpc.ServiceDesc for Encyclopedia service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Encyclopedia_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wikipedia.Encyclopedia",
+	HandlerType: (*EncyclopediaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lookup",
+			Handler:    _Encyclopedia_Lookup_Handler,
+		},
+		{
+			MethodName: "Complete",
+			Handler:    _Encyclopedia_Complete_Handler,
+		},
+		{
+			MethodName: "Backlinks",
+			Handler:    _Encyclopedia_Backlinks_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Search",
+			Handler:       _Encyclopedia_Search_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "wikipedia.proto",
+}
@@ -5,7 +5,15 @@
 package wikipedia
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/boltdb/bolt"
 )
 
 func TestWikiTextToHTMLULists(t *testing.T) {
@@ -17,7 +25,10 @@ func TestWikiTextToHTMLULists(t *testing.T) {
 *** Test 3 Again
 * Test 1 Again
 End Test`
-	html := WikiTextToHTML(text)
+	html, err := WikiTextToHTML(text)
+	if err != nil {
+		t.Fatal(err)
+	}
 	target := `This is a test
 <ul>
  <li>Test 1
@@ -49,7 +60,10 @@ func TestWikiTextToHTMLOLists(t *testing.T) {
 ### Test 3 Again
 # Test 1 Again
 End Test`
-	html := WikiTextToHTML(text)
+	html, err := WikiTextToHTML(text)
+	if err != nil {
+		t.Fatal(err)
+	}
 	target := `This is a test
 <ol>
  <li>Test 1
@@ -74,9 +88,595 @@ End Test`
 
 func TestWikiTextToHTMLCite(t *testing.T) {
 	text := `<ref>{{cite act |date=March 3, 1931 |article=14 |article-type=H.R. |legislature=[[71st United States Congress]] |title=An Act To make The Star-Spangled Banner the national anthem of the United States of America |url=https://uscode.house.gov/statviewer.htm?volume=46&page=1508}}</ref>`
-	html := WikiTextToHTML(text)
+	html, err := WikiTextToHTML(text)
+	if err != nil {
+		t.Fatal(err)
+	}
 	target := `<sup class="tooltip">0<span class="tooltiptext"><ref>{{cite act |date=March 3, 1931 |article=14 |article-type=H.R. |legislature=[[71st United States Congress]] |title=An Act To make The Star-Spangled Banner the national anthem of the United States of America |url=https://uscode.house.gov/statviewer.htm?volume=46&page=1508}}</ref></span></sup>`
 	if html != target {
 		t.Fatalf("not equal %s", html)
 	}
 }
+
+func TestWikiTextToHTMLApostrophes(t *testing.T) {
+	test := func(text, target string) {
+		html, err := WikiTextToHTML(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if html != target {
+			t.Fatalf("not equal %q %q", text, html)
+		}
+	}
+	test("''italic'' and '''bold''' and '''''both'''''", "<i>italic</i> and <b>bold</b> and <i><b>both</b></i>")
+	test("'''''both''' italic''", "<i><b>both</b> italic</i>")
+	test("'''''both'' bold'''", "<b><i>both</i> bold</b>")
+	test("'''bold ''both''' italic''", "<b>bold <i>both</i></b><i> italic</i>")
+	test("''unclosed\nnext '''line", "<i>unclosed</i>\nnext <b>line</b>")
+	test("'''bold\r\nnext", "<b>bold</b>\r\nnext")
+	test("l''''arbre", "l'<b>arbre</b>")
+	test("a '''''''b''", "a ''<b><i>b</i></b>")
+	test("John's car", "John's car")
+	test("the b'''c d''", "the b'<i>c d</i>")
+	test("the bb'''c d''", "the bb'<i>c d</i>")
+	test("the '''c d''", "the '<i>c d</i>")
+	test("Paris '''is''' ''not'' '''the''' x'''y ''z''", "Paris <b>is</b> <i>not</i> <b>the</b> x<b>y <i>z</i></b>")
+	test("* ''item'' '''bold\n* next\n", "<ul>\n <li><i>item</i> <b>bold</b> </li>\n  <li>next</li>\n</ul>\n")
+	test("== The ''Title'' ==\n", "<h2 id=\"The_Title\">The <i>Title</i></h2>\n")
+	test("[[Apple|''Malus'']]", "<a href=\"/wiki/article/Apple\"><i>Malus</i></a>")
+}
+
+func TestWikiTextToHTMLFormatting(t *testing.T) {
+	test := func(text, target string) {
+		html, err := WikiTextToHTML(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if html != target {
+			t.Fatalf("not equal %q %q", text, html)
+		}
+	}
+	test("<b>B</b> <i>I</i> <u>U</u> <s>S</s>", "<b>B</b> <i>I</i> <u>U</u> <s>S</s>")
+	test("H<sub>2</sub>O x<sup>2</sup> <small>small</small> <big>big</big>", "H<sub>2</sub>O x<sup>2</sup> <small>small</small> <big>big</big>")
+	test("<B>bold</B> <sub class=\"x\">i</SUB>", "<b>bold</b> <sub>i</sub>")
+	test("<big>big <i>italic</big>", "<big>big <i>italic</i></big>")
+	test("<u>open", "<u>open</u>")
+	test("close</s> <small/>", "close ")
+	test("<span>x</span> <br/>", "<span>x</span> <br/>")
+	test("", "")
+}
+
+func TestWikiTextToText(t *testing.T) {
+	text := "'''Apple''' is a [[fruit]] of [[Malus|apple trees]].<ref>{{cite web |title=Apples}}</ref>\n" +
+		"== Uses ==\n* [[Cider]]\n** pie\n----\n[[Category:Fruits]]"
+	plain, err := WikiTextToText(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := "Apple is a fruit of apple trees.\n\nUses\nCider\npie\n\n"
+	if plain != target {
+		t.Fatalf("not equal %q", plain)
+	}
+
+	plain, err = WikiTextToText("l''''arbre is H<sub>2</sub>O in [[Water|''eau'']]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target := "l'arbre is H2O in eau"; plain != target {
+		t.Fatalf("not equal %q", plain)
+	}
+
+	plain, err = WikiTextToText("Cities\n{|\n|+ ''Capitals''\n! City !! Country\n|-\n| [[Paris]] || France\n|}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target := "Cities\nCapitals\nCity Country\nParis France\n"; plain != target {
+		t.Fatalf("not equal %q", plain)
+	}
+
+	if plain, err := WikiTextToText(""); err != nil || plain != "" {
+		t.Fatal("empty text should be empty", plain, err)
+	}
+}
+
+// testOptions are the options for building from the test dump
+func testOptions(dir string) Options {
+	return Options{
+		Dump: filepath.Join("testdata", "pages-articles.xml.bz2"),
+		DB:   filepath.Join(dir, "test.db"),
+	}
+}
+
+// testMultistreamOptions are the options for building from the multistream test dump
+func testMultistreamOptions(dir string) Options {
+	return Options{
+		Dump:  filepath.Join("testdata", "pages-articles-multistream.xml.bz2"),
+		Index: filepath.Join("testdata", "pages-articles-multistream-index.txt.bz2"),
+		DB:    filepath.Join(dir, "test.db"),
+	}
+}
+
+// testEncyclopedia builds and opens an encyclopedia from the test dump
+func testEncyclopedia(t *testing.T) (*Encyclopedia, func()) {
+	return testBuild(t, testOptions)
+}
+
+// testBuild builds and opens an encyclopedia with the given options
+func testBuild(t *testing.T, test func(dir string) Options) (*Encyclopedia, func()) {
+	dir, err := ioutil.TempDir("", "wikipedia")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := test(dir)
+	err = Build(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Rank(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options.Bolt = &bolt.Options{ReadOnly: true}
+	encyclopedia, err := Open(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encyclopedia, func() {
+		encyclopedia.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	article, err := encyclopedia.Lookup("New York City")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if article == nil {
+		t.Fatal("article should be found")
+	}
+	if article.ID != 645042 {
+		t.Fatal("invalid article id", article.ID)
+	}
+	results, err := encyclopedia.Search(SearchRequest{Query: "capital"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 2 {
+		t.Fatal("there should be 2 results", len(results.Results))
+	}
+}
+
+func TestLookupRevision(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	article, err := encyclopedia.Lookup("New York City")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if article.Revision != 1031211111 || article.Parent != 1031100000 {
+		t.Fatal("invalid revision", article.Revision, article.Parent)
+	}
+	if article.Timestamp != "2021-06-28T14:02:11Z" {
+		t.Fatal("invalid timestamp", article.Timestamp)
+	}
+	if article.Contributor() != "192.0.2.1" || article.Username != "" {
+		t.Fatal("invalid contributor", article.Contributor())
+	}
+	if article.Model != "wikitext" || article.Format != "text/x-wiki" {
+		t.Fatal("invalid model", article.Model, article.Format)
+	}
+	if article.SHA1 != "cc05kb9x838cdkogravo34255c2aqcu" || !article.Verify() {
+		t.Fatal("text should match its sha1", article.SHA1, Checksum(article.Text))
+	}
+	article.Text += "vandalism"
+	if article.Verify() {
+		t.Fatal("changed text should not match its sha1")
+	}
+}
+
+func TestSearchScore(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	statistics, err := encyclopedia.Statistics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statistics.Documents != 7 || statistics.Length == 0 {
+		t.Fatal("invalid statistics", statistics)
+	}
+	search := func(weight float64, first string) []Result {
+		encyclopedia.Options.Weight = &weight
+		results, err := encyclopedia.Search(SearchRequest{Query: "york"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results.Results) != 4 || results.Results[0].Article.Title != first {
+			t.Fatal("invalid first result", weight, len(results.Results), results.Results[0].Article.Title)
+		}
+		return results.Results
+	}
+	search(100, "United States")
+	results := search(1e-6, "New York City")
+	for i, result := range results {
+		if result.Score <= 0 {
+			t.Fatal("invalid score", result.Article.Title, result.Score)
+		} else if i > 0 && result.Score > results[i-1].Score {
+			t.Fatal("results should be ordered by score")
+		}
+	}
+
+	// a zero weight and a zero length normalization are kept
+	zero := 0.0
+	options := Options{B: &zero, Weight: &zero}.defaults()
+	if *options.B != 0 || *options.Weight != 0 || *options.K1 != DefaultK1 {
+		t.Fatal("zero options should be kept", *options.B, *options.Weight, *options.K1)
+	}
+	unranked := search(0, "New York City")
+	for i, result := range unranked {
+		if result.Score >= results[i].Score {
+			t.Fatal("the rank should not be scored with a zero weight", result.Article.Title, result.Score)
+		}
+	}
+	encyclopedia.Options.B = &zero
+	unnormalized, different := search(0, "New York City"), false
+	for i, result := range unnormalized {
+		different = different || result.Score != unranked[i].Score
+	}
+	if !different {
+		t.Fatal("the length should not be scored with a zero length normalization")
+	}
+}
+
+func TestBuildMultistream(t *testing.T) {
+	encyclopedia, cleanup := testBuild(t, testMultistreamOptions)
+	defer cleanup()
+	for _, title := range []string{"United States", "New York City", "Washington, D.C.", "Apple"} {
+		article, err := encyclopedia.Lookup(title)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if article == nil || article.Title != title {
+			t.Fatal("article should be found", title)
+		}
+	}
+	results, err := encyclopedia.Search(SearchRequest{Query: "capital"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 2 {
+		t.Fatal("there should be 2 results", len(results.Results))
+	}
+}
+
+func TestBuildResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wikipedia")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cpus := NumCPU
+	NumCPU = 1
+	defer func() {
+		NumCPU = cpus
+	}()
+
+	// the dump is cut in the middle of its second stream of pages
+	dump, err := ioutil.ReadFile(filepath.Join("testdata", "pages-articles-multistream.xml.bz2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := testMultistreamOptions(dir)
+	options.Dump = filepath.Join(dir, filepath.Base(options.Dump))
+	options.Checkpoint = 1
+	err = ioutil.WriteFile(options.Dump, dump[:1172], 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Build(options)
+	if err == nil {
+		t.Fatal("build of a truncated dump should fail")
+	}
+	encyclopedia, err := Open(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err := encyclopedia.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encyclopedia.Close()
+	if checkpoint.Page != 3434750 || checkpoint.Offset != 359 || checkpoint.Done {
+		t.Fatal("invalid checkpoint", checkpoint)
+	}
+
+	err = ioutil.WriteFile(options.Dump, dump, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Build(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encyclopedia, err = Open(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer encyclopedia.Close()
+	checkpoint, err = encyclopedia.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !checkpoint.Done {
+		t.Fatal("build should be done")
+	}
+	err = encyclopedia.DB.View(func(tx *bolt.Tx) error {
+		if n := tx.Bucket([]byte("wiki")).Stats().KeyN; n != 7 {
+			t.Fatal("there should be 7 titles", n)
+		}
+		if n := tx.Bucket([]byte("pages")).Stats().KeyN; n != 7 {
+			t.Fatal("there should be 7 pages", n)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := encyclopedia.Search(SearchRequest{Query: "city"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 4 {
+		t.Fatal("there should be 4 results", len(results.Results))
+	}
+	for _, result := range results.Results {
+		if result.Count != 1 {
+			t.Fatal("the index should not have duplicates", result.Article.Title, result.Count)
+		}
+	}
+}
+
+func TestLookupRedirect(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	test := func(title, redirect, anchor string) {
+		article, err := encyclopedia.Lookup(title)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if article == nil || article.Title != "United States" {
+			t.Fatal("redirect should be followed", title)
+		}
+		if article.Redirect != redirect || article.Anchor != anchor {
+			t.Fatal("invalid redirect", title, article.Redirect, article.Anchor)
+		}
+	}
+	test("United States", "", "")
+	test("USA", "USA", "")
+	test("US cities", "US cities", "Cities")
+	test("America", "America", "")
+
+	results, err := encyclopedia.Search(SearchRequest{Query: "abbreviation"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 0 {
+		t.Fatal("redirects should not be indexed", len(results.Results))
+	}
+}
+
+func TestBacklinks(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	test := func(title string, expected ...string) {
+		titles, err := encyclopedia.Backlinks(title)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(titles, "|") != strings.Join(expected, "|") {
+			t.Fatal("invalid backlinks", title, titles)
+		}
+	}
+	test("USA", "New York City", "Talk:United States", "Washington, D.C.")
+	test("New York City", "Apple", "United States", "Washington, D.C.")
+	test("Apple")
+	if titles, err := encyclopedia.Backlinks("Atlantis"); err != nil || titles != nil {
+		t.Fatal("article should not be found", titles, err)
+	}
+
+	// the indexes of the backlinks are stored instead of the titles
+	err := encyclopedia.DB.View(func(tx *bolt.Tx) error {
+		key := tx.Bucket([]byte("wiki")).Get([]byte("New York City"))
+		links, err := getBacklinks(tx.Bucket([]byte("backlinks")), key)
+		if err != nil {
+			return err
+		}
+		if len(links.Indexes) != 3 {
+			t.Fatal("invalid stored backlinks", links.Indexes)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLookupNormalized(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	test := func(title, expected, redirect string) {
+		article, err := encyclopedia.Lookup(title)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if article == nil || article.Title != expected || article.Redirect != redirect {
+			t.Fatal("article should be found", title)
+		}
+	}
+	test("united states", "United States", "")
+	test("United_States", "United States", "")
+	test(" United  States ", "United States", "")
+	test("NEW YORK CITY", "New York City", "")
+	test("Zu\u0308rich", "Zürich", "")
+	test("us_Cities", "United States", "US cities")
+
+	// a case variant redirect leads to the same article
+	test("new york city", "New York City", "")
+	test("New_York_City", "New York City", "")
+	test("New york city", "New York City", "New york city")
+
+	article, err := encyclopedia.Lookup("United Kingdom")
+	if err != nil {
+		t.Fatal(err)
+	} else if article != nil {
+		t.Fatal("article should not be found")
+	}
+	_, err = encyclopedia.Lookup("usa")
+	if ambiguous, ok := err.(*AmbiguousError); !ok {
+		t.Fatal("title should be ambiguous", err)
+	} else if fmt.Sprint(ambiguous.Titles) != "[USA Usa]" {
+		t.Fatal("invalid titles", ambiguous.Titles)
+	}
+	test("USA", "United States", "USA")
+
+	if title := NormalizeTitle("  Straße_in\tZu\u0308rich "); title != "strasse in zürich" {
+		t.Fatal("invalid normalized title", title)
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	namespaces, err := encyclopedia.Namespaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if namespaces[0] != "" || namespaces[1] != "Talk" || namespaces[10] != "Template" {
+		t.Fatal("invalid namespace table", namespaces)
+	}
+	article, err := encyclopedia.Lookup("Talk:United States")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if article == nil || article.Namespace != 1 {
+		t.Fatal("talk page should be in namespace 1", article)
+	}
+
+	test := func(query string, expected int, namespaces ...int32) {
+		results, err := encyclopedia.Search(SearchRequest{Query: query, Namespaces: namespaces})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results.Results) != expected {
+			t.Fatal("invalid number of results", query, namespaces, len(results.Results))
+		}
+	}
+	test("discussion", 2)
+	test("discussion", 0, 0)
+	test("discussion", 1, 1)
+	test("discussion", 2, 1, 10)
+	test("capital", 2, 0)
+
+	parsed, err := encyclopedia.ParseNamespaces("0, talk ,Template,14")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(parsed) != "[0 1 10 14]" {
+		t.Fatal("invalid namespaces", parsed)
+	}
+	if _, err := encyclopedia.ParseNamespaces("Nowhere"); err == nil {
+		t.Fatal("an unknown namespace should fail")
+	}
+	if _, err := ParseNamespaces("Talk", nil); err == nil {
+		t.Fatal("a namespace name should fail without a namespace table")
+	}
+
+	excluded, cleanup := testBuild(t, func(dir string) Options {
+		options := testOptions(dir)
+		options.Exclude = []int32{10}
+		return options
+	})
+	defer cleanup()
+	results, err := excluded.Search(SearchRequest{Query: "discussion"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 1 || results.Results[0].Article.Title != "Talk:United States" {
+		t.Fatal("the template namespace should be excluded", len(results.Results))
+	}
+
+	included, cleanup := testBuild(t, func(dir string) Options {
+		options := testOptions(dir)
+		options.Include = []int32{0}
+		return options
+	})
+	defer cleanup()
+	results, err = included.Search(SearchRequest{Query: "discussion"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 0 {
+		t.Fatal("only the main namespace should be included", len(results.Results))
+	}
+}
+
+func TestSearchPage(t *testing.T) {
+	encyclopedia, cleanup := testEncyclopedia(t)
+	defer cleanup()
+	all, err := encyclopedia.Search(SearchRequest{Query: "york"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all.Total != 4 || len(all.Results) != 4 {
+		t.Fatal("there should be 4 results", all.Total, len(all.Results))
+	}
+	for offset := 0; offset < 6; offset += 2 {
+		results, err := encyclopedia.Search(SearchRequest{Query: "york", Offset: offset, Limit: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if results.Total != 4 {
+			t.Fatal("invalid total", results.Total)
+		}
+		expected := all.Results[offset:]
+		if len(expected) > 2 {
+			expected = expected[:2]
+		}
+		if len(results.Results) != len(expected) {
+			t.Fatal("invalid page", offset, len(results.Results))
+		}
+		for i, result := range results.Results {
+			if result.Index != expected[i].Index || result.Article == nil {
+				t.Fatal("invalid result", offset, i, result.Index)
+			}
+		}
+	}
+	results, err := encyclopedia.Search(SearchRequest{Query: "york", Candidates: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 4 || results.Scored != 2 || len(results.Results) != 2 {
+		t.Fatal("the candidates should be limited", results.Total, results.Scored)
+	}
+	// the candidates are the articles with the highest ranks
+	ranks := make([]float64, 0, len(all.Results))
+	for _, result := range all.Results {
+		ranks = append(ranks, float64(result.Rank))
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(ranks)))
+	for _, result := range results.Results {
+		if float64(result.Rank) < ranks[1] {
+			t.Fatal("the candidates should be cut by rank", result.Article.Title, result.Rank)
+		}
+	}
+	results, err = encyclopedia.Search(SearchRequest{Query: "discussion", Namespaces: []int32{10}, Candidates: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 1 || len(results.Results) != 1 || results.Results[0].Article.Title != "Template:Navbox" {
+		t.Fatal("the namespaces should be filtered before the candidates are limited", results.Total)
+	}
+}